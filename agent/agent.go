@@ -0,0 +1,178 @@
+// Package agent implements the server side of execx's remote execution
+// transport: it listens for framed requests (see internal/wire) and runs
+// the requested pipeline with the host's os/exec, streaming stdout/stderr
+// back to the client as it's produced.
+package agent
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"os/exec"
+	"sync"
+	"syscall"
+
+	"github.com/goforj/execx/internal/wire"
+)
+
+// Serve listens on network/address (e.g. "tcp", "127.0.0.1:9123", or
+// "unix", "/run/execx-agent.sock") and serves remote execx requests until
+// the listener is closed or an Accept error occurs.
+// @group Agent
+//
+// Example: serve
+//
+//	ln, _ := net.Listen("tcp", "127.0.0.1:0")
+//	go agent.ServeListener(ln)
+//	defer ln.Close()
+//	fmt.Println(ln.Addr() != nil)
+//	// #bool true
+func Serve(network, address string) error {
+	ln, err := net.Listen(network, address)
+	if err != nil {
+		return err
+	}
+	defer ln.Close()
+	return ServeListener(ln)
+}
+
+// ServeListener serves remote execx requests on an already-bound listener,
+// so callers that need the assigned address (e.g. "tcp", "127.0.0.1:0")
+// can inspect ln.Addr() before requests start arriving.
+// @group Agent
+//
+// Example: serve listener
+//
+//	ln, _ := net.Listen("tcp", "127.0.0.1:0")
+//	go agent.ServeListener(ln)
+//	defer ln.Close()
+//	fmt.Println(ln.Addr() != nil)
+//	// #bool true
+func ServeListener(ln net.Listener) error {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return err
+		}
+		go handleConn(conn)
+	}
+}
+
+func handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	var envelope wire.Envelope
+	if err := wire.ReadMessage(conn, &envelope); err != nil || envelope.Request == nil {
+		return
+	}
+	req := *envelope.Request
+
+	cmds := make([]*exec.Cmd, len(req.Stages))
+	for i, st := range req.Stages {
+		cmd := exec.Command(st.Name, st.Args...)
+		cmd.Dir = st.Dir
+		if len(st.Env) > 0 {
+			cmd.Env = st.Env
+		}
+		cmds[i] = cmd
+	}
+	if len(cmds) == 0 {
+		_ = wire.WriteMessage(conn, wire.Envelope{Response: &wire.Response{Err: "no stages in request"}})
+		return
+	}
+
+	var writeMu sync.Mutex
+	send := func(f wire.StreamFrame) {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		_ = wire.WriteMessage(conn, wire.Envelope{Stream: &f})
+	}
+
+	stdoutBufs := make([]bytes.Buffer, len(cmds))
+	stderrBufs := make([]bytes.Buffer, len(cmds))
+	pipeWriters := make([]*io.PipeWriter, len(cmds))
+
+	for i, cmd := range cmds {
+		idx := i
+		cmd.Stdout = io.MultiWriter(&stdoutBufs[idx], &streamWriter{kind: wire.StreamStdout, stage: idx, send: send})
+		cmd.Stderr = io.MultiWriter(&stderrBufs[idx], &streamWriter{kind: wire.StreamStderr, stage: idx, send: send})
+		if idx == 0 {
+			if len(req.Stdin) > 0 {
+				cmd.Stdin = bytes.NewReader(req.Stdin)
+			}
+			continue
+		}
+		reader, writer := io.Pipe()
+		pipeWriters[idx-1] = writer
+		cmd.Stdin = reader
+		cmds[idx-1].Stdout = io.MultiWriter(cmds[idx-1].Stdout, writer)
+	}
+
+	go forwardSignals(conn, cmds)
+
+	startErrs := make([]error, len(cmds))
+	for i, cmd := range cmds {
+		startErrs[i] = cmd.Start()
+		if startErrs[i] != nil {
+			for j := i; j < len(cmds); j++ {
+				startErrs[j] = startErrs[i]
+			}
+			break
+		}
+	}
+
+	results := make([]wire.StageResult, len(cmds))
+	for i, cmd := range cmds {
+		var waitErr error
+		if startErrs[i] == nil {
+			waitErr = cmd.Wait()
+		} else {
+			waitErr = startErrs[i]
+		}
+		if pipeWriters[i] != nil {
+			_ = pipeWriters[i].Close()
+		}
+		results[i] = wire.StageResult{
+			Stdout:   stdoutBufs[i].String(),
+			Stderr:   stderrBufs[i].String(),
+			ExitCode: -1,
+		}
+		if cmd.ProcessState != nil {
+			results[i].ExitCode = cmd.ProcessState.ExitCode()
+		}
+		if waitErr != nil && results[i].ExitCode == -1 {
+			results[i].Err = waitErr.Error()
+		}
+	}
+
+	_ = wire.WriteMessage(conn, wire.Envelope{Response: &wire.Response{Results: results}})
+}
+
+func forwardSignals(conn net.Conn, cmds []*exec.Cmd) {
+	for {
+		var envelope wire.Envelope
+		if err := wire.ReadMessage(conn, &envelope); err != nil {
+			return
+		}
+		if envelope.Stream == nil || envelope.Stream.Kind != wire.StreamSignal {
+			continue
+		}
+		for _, cmd := range cmds {
+			if cmd.Process != nil {
+				_ = cmd.Process.Signal(syscall.Signal(envelope.Stream.Signal))
+			}
+		}
+	}
+}
+
+type streamWriter struct {
+	kind  wire.StreamKind
+	stage int
+	send  func(wire.StreamFrame)
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	data := append([]byte{}, p...)
+	w.send(wire.StreamFrame{Kind: w.kind, Stage: w.stage, Data: data})
+	return len(p), nil
+}
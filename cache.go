@@ -0,0 +1,137 @@
+package execx
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Cache stores command results keyed by a digest of the command that
+// produced them, letting Run skip re-spawning a process whose output is
+// already known.
+type Cache interface {
+	Get(key string) (Result, bool)
+	Put(key string, result Result)
+}
+
+// WithCache enables result caching for the whole pipeline using the given
+// backend. Caching is automatically skipped for commands that stream
+// output (StdoutWriter/OnStdout/OnStderr/CaptureEvents), that use
+// WithTimeout/WithDeadline, or that exit non-zero, unless CacheNonZero is
+// also set.
+// @group Caching
+//
+// Example: with cache
+//
+//	cache := execx.NewMemoryCache(64)
+//	cmd := func() (execx.Result, error) {
+//		return execx.Command("go", "env", "GOOS").WithCache(cache).Run()
+//	}
+//	first, _ := cmd()
+//	second, _ := cmd()
+//	fmt.Println(first.Stdout == second.Stdout)
+//	// #bool true
+func (c *Cmd) WithCache(cache Cache) *Cmd {
+	c.rootCmd().cache = cache
+	return c
+}
+
+// NoCache disables result caching for this command.
+// @group Caching
+//
+// Example: no cache
+//
+//	cmd := execx.Command("go", "env", "GOOS").WithCache(execx.NewMemoryCache(8)).NoCache()
+//	fmt.Println(cmd != nil)
+//	// #bool true
+func (c *Cmd) NoCache() *Cmd {
+	c.rootCmd().cache = nil
+	return c
+}
+
+// CacheNonZero allows results with a non-zero exit code to be cached.
+// @group Caching
+//
+// Example: cache non zero
+//
+//	cmd := execx.Command("false").WithCache(execx.NewMemoryCache(8)).CacheNonZero()
+//	fmt.Println(cmd != nil)
+//	// #bool true
+func (c *Cmd) CacheNonZero() *Cmd {
+	c.rootCmd().cacheNonZero = true
+	return c
+}
+
+// cacheEligible reports whether this pipeline can participate in result
+// caching at all: no streaming consumers, and no timeout/deadline (whose
+// outcome can vary run to run).
+func (c *Cmd) cacheEligible() bool {
+	for stage := c.rootCmd(); stage != nil; stage = stage.next {
+		if stage.stdoutW != nil || stage.stderrW != nil || stage.onStdout != nil || stage.onStderr != nil || stage.events != nil {
+			return false
+		}
+		if stage.cancel != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// cacheKey hashes {argv, dir, env, stdin} for every stage so that `A | B`
+// and `A | C` never collide. The env component is the fully resolved
+// environment (buildEnv), so EnvAllow/EnvDeny and live os.Environ() values
+// are part of the key, not just the literal Env() overrides.
+func (c *Cmd) cacheKey() (string, bool) {
+	h := sha256.New()
+	for stage := c.rootCmd(); stage != nil; stage = stage.next {
+		fmt.Fprintf(h, "argv:%q\n", stage.Args())
+		fmt.Fprintf(h, "dir:%q\n", stage.dir)
+		fmt.Fprintf(h, "env:%s\n", strings.Join(buildEnv(stage.envMode, stage.env, stage.envAllow, stage.envDeny), ","))
+		stdin, ok := stageStdinDigest(stage.stdin)
+		if !ok {
+			return "", false
+		}
+		fmt.Fprintf(h, "stdin:%s\n", stdin)
+	}
+	return hex.EncodeToString(h.Sum(nil)), true
+}
+
+// stageStdinDigest hashes the stdin content when it is known up front
+// (string/bytes) and reports false when it can't be hashed without
+// consuming an arbitrary reader, which makes the command cache-ineligible.
+func stageStdinDigest(stdin any) (string, bool) {
+	switch v := stdin.(type) {
+	case nil:
+		return "", true
+	case *strings.Reader:
+		sum := sha256.Sum256([]byte(readerContents(v)))
+		return hex.EncodeToString(sum[:]), true
+	case *bytes.Reader:
+		sum := sha256.Sum256([]byte(readerContents(v)))
+		return hex.EncodeToString(sum[:]), true
+	default:
+		return "", false
+	}
+}
+
+type sizer interface {
+	Size() int64
+}
+
+func readerContents(r interface {
+	ReadAt([]byte, int64) (int, error)
+	sizer
+}) string {
+	buf := make([]byte, r.Size())
+	_, _ = r.ReadAt(buf, 0)
+	return string(buf)
+}
+
+// cachedSynthesize builds a Result as if the command had just run, for a
+// cache hit.
+func cachedSynthesize(result Result) Result {
+	result.Err = nil
+	return result
+}
@@ -0,0 +1,62 @@
+package execx
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// NewFileCache returns a Cache backend that persists each Result as a JSON
+// file named after its key under dir, so results survive across process
+// restarts.
+// @group Caching
+//
+// Example: file cache
+//
+//	cache := execx.NewFileCache(os.TempDir())
+//	cache.Put("key", execx.Result{Stdout: "hi"})
+//	res, ok := cache.Get("key")
+//	fmt.Println(ok && res.Stdout == "hi")
+//	// #bool true
+func NewFileCache(dir string) *FileCache {
+	return &FileCache{dir: dir}
+}
+
+// FileCache is a filesystem-backed Cache.
+type FileCache struct {
+	dir string
+}
+
+type fileCacheEntry struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+}
+
+// Get returns a cached Result for key, if a matching file exists.
+func (f *FileCache) Get(key string) (Result, bool) {
+	data, err := os.ReadFile(f.path(key))
+	if err != nil {
+		return Result{}, false
+	}
+	var entry fileCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return Result{}, false
+	}
+	return Result{Stdout: entry.Stdout, Stderr: entry.Stderr, ExitCode: entry.ExitCode}, true
+}
+
+// Put writes result to disk under key.
+func (f *FileCache) Put(key string, result Result) {
+	entry := fileCacheEntry{Stdout: result.Stdout, Stderr: result.Stderr, ExitCode: result.ExitCode}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	_ = os.MkdirAll(f.dir, 0o755)
+	_ = os.WriteFile(f.path(key), data, 0o644)
+}
+
+func (f *FileCache) path(key string) string {
+	return filepath.Join(f.dir, key+".json")
+}
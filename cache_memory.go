@@ -0,0 +1,75 @@
+package execx
+
+import (
+	"container/list"
+	"sync"
+)
+
+// NewMemoryCache returns an in-memory, goroutine-safe Cache bounded to the
+// given number of entries, evicting the least recently used entry once
+// full.
+// @group Caching
+//
+// Example: memory cache
+//
+//	cache := execx.NewMemoryCache(32)
+//	cache.Put("key", execx.Result{Stdout: "hi"})
+//	res, ok := cache.Get("key")
+//	fmt.Println(ok && res.Stdout == "hi")
+//	// #bool true
+func NewMemoryCache(capacity int) *MemoryCache {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &MemoryCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  map[string]*list.Element{},
+	}
+}
+
+// MemoryCache is a fixed-capacity LRU Cache backend.
+type MemoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key    string
+	result Result
+}
+
+// Get returns a cached Result for key, if present.
+func (m *MemoryCache) Get(key string) (Result, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	elem, ok := m.entries[key]
+	if !ok {
+		return Result{}, false
+	}
+	m.order.MoveToFront(elem)
+	return elem.Value.(*memoryCacheEntry).result, true
+}
+
+// Put stores a Result for key, evicting the least recently used entry if
+// the cache is at capacity.
+func (m *MemoryCache) Put(key string, result Result) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if elem, ok := m.entries[key]; ok {
+		elem.Value.(*memoryCacheEntry).result = result
+		m.order.MoveToFront(elem)
+		return
+	}
+	elem := m.order.PushFront(&memoryCacheEntry{key: key, result: result})
+	m.entries[key] = elem
+	if m.order.Len() > m.capacity {
+		oldest := m.order.Back()
+		if oldest != nil {
+			m.order.Remove(oldest)
+			delete(m.entries, oldest.Value.(*memoryCacheEntry).key)
+		}
+	}
+}
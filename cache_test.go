@@ -0,0 +1,63 @@
+package execx
+
+import "testing"
+
+func TestMemoryCacheHitAndEviction(t *testing.T) {
+	cache := NewMemoryCache(1)
+	cache.Put("a", Result{Stdout: "a"})
+	cache.Put("b", Result{Stdout: "b"})
+	if _, ok := cache.Get("a"); ok {
+		t.Fatalf("expected a to be evicted")
+	}
+	if res, ok := cache.Get("b"); !ok || res.Stdout != "b" {
+		t.Fatalf("expected b to be cached, got %+v ok=%v", res, ok)
+	}
+}
+
+func TestFileCacheRoundTrip(t *testing.T) {
+	cache := NewFileCache(t.TempDir())
+	cache.Put("key", Result{Stdout: "hi", ExitCode: 0})
+	res, ok := cache.Get("key")
+	if !ok || res.Stdout != "hi" {
+		t.Fatalf("expected cached result, got %+v ok=%v", res, ok)
+	}
+	if _, ok := cache.Get("missing"); ok {
+		t.Fatalf("expected miss for unknown key")
+	}
+}
+
+func TestRunUsesCache(t *testing.T) {
+	cache := NewMemoryCache(8)
+	first, err := helperCommand("echo", "hi").WithCache(cache).Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	second, err := helperCommand("echo", "hi").WithCache(cache).Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if first.Stdout != second.Stdout {
+		t.Fatalf("expected cached stdout to match: %q vs %q", first.Stdout, second.Stdout)
+	}
+}
+
+func TestCacheKeyDiffersByEnvAllow(t *testing.T) {
+	a := helperCommand("echo", "hi").HermeticEnv().EnvAllow("PATH")
+	b := helperCommand("echo", "hi").HermeticEnv().EnvAllow("HOME")
+	keyA, okA := a.cacheKey()
+	keyB, okB := b.cacheKey()
+	if !okA || !okB {
+		t.Fatalf("expected both commands to be cache-key-eligible")
+	}
+	if keyA == keyB {
+		t.Fatalf("expected EnvAllow(\"PATH\") and EnvAllow(\"HOME\") to produce different cache keys")
+	}
+}
+
+func TestCacheSkippedWhenStreaming(t *testing.T) {
+	cache := NewMemoryCache(8)
+	cmd := helperCommand("echo", "hi").WithCache(cache).OnStdout(func(string) {})
+	if cmd.cacheEligible() {
+		t.Fatalf("expected streaming command to be cache-ineligible")
+	}
+}
@@ -0,0 +1,178 @@
+package execx
+
+import (
+	"errors"
+	"os"
+)
+
+// ErrDaemonizeUnsupported is returned when Daemonize is used on a
+// platform with neither fork/session detachment (Unix) nor detached
+// creation flags (Windows) to fall back on.
+var ErrDaemonizeUnsupported = errors.New("execx: Daemonize is not supported on this platform")
+
+// daemonConfig collects the options a DaemonizeOption sets.
+type daemonConfig struct {
+	dir     string
+	umask   int
+	stdin   string
+	stdout  string
+	stderr  string
+	pidFile string
+}
+
+func newDaemonConfig() daemonConfig {
+	return daemonConfig{
+		dir:    "/",
+		umask:  0o22,
+		stdin:  os.DevNull,
+		stdout: os.DevNull,
+		stderr: os.DevNull,
+	}
+}
+
+// DaemonizeOption configures the daemon Daemonize detaches.
+type DaemonizeOption func(*daemonConfig)
+
+// WithDaemonDir sets the daemon's working directory after it detaches.
+// Defaults to "/", the conventional daemon chdir target so it doesn't
+// pin whatever filesystem the launching process happened to be in.
+// @group Process
+//
+// Example: with daemon dir
+//
+//	d, err := execx.Command("sleep", "1").Daemonize(execx.WithDaemonDir("/tmp"))
+//	fmt.Println(err == nil && d.Pid > 0)
+//	// #bool true
+func WithDaemonDir(dir string) DaemonizeOption {
+	return func(cfg *daemonConfig) { cfg.dir = dir }
+}
+
+// WithDaemonUmask sets the daemon's umask. Defaults to 022.
+// @group Process
+//
+// Example: with daemon umask
+//
+//	d, err := execx.Command("sleep", "1").Daemonize(execx.WithDaemonUmask(0o77))
+//	fmt.Println(err == nil && d.Pid > 0)
+//	// #bool true
+func WithDaemonUmask(mask int) DaemonizeOption {
+	return func(cfg *daemonConfig) { cfg.umask = mask }
+}
+
+// WithDaemonStdin redirects the daemon's stdin to path instead of
+// os.DevNull.
+// @group Process
+//
+// Example: with daemon stdin
+//
+//	d, err := execx.Command("cat").Daemonize(execx.WithDaemonStdin(os.DevNull))
+//	fmt.Println(err == nil && d.Pid > 0)
+//	// #bool true
+func WithDaemonStdin(path string) DaemonizeOption {
+	return func(cfg *daemonConfig) { cfg.stdin = path }
+}
+
+// WithDaemonStdout redirects the daemon's stdout to path instead of
+// os.DevNull.
+// @group Process
+//
+// Example: with daemon stdout
+//
+//	d, err := execx.Command("echo", "hi").Daemonize(execx.WithDaemonStdout(os.DevNull))
+//	fmt.Println(err == nil && d.Pid > 0)
+//	// #bool true
+func WithDaemonStdout(path string) DaemonizeOption {
+	return func(cfg *daemonConfig) { cfg.stdout = path }
+}
+
+// WithDaemonStderr redirects the daemon's stderr to path instead of
+// os.DevNull.
+// @group Process
+//
+// Example: with daemon stderr
+//
+//	d, err := execx.Command("sleep", "1").Daemonize(execx.WithDaemonStderr(os.DevNull))
+//	fmt.Println(err == nil && d.Pid > 0)
+//	// #bool true
+func WithDaemonStderr(path string) DaemonizeOption {
+	return func(cfg *daemonConfig) { cfg.stderr = path }
+}
+
+// WithDaemonPidFile writes the daemon's PID to path once the readiness
+// handshake confirms it started.
+// @group Process
+//
+// Example: with daemon pidfile
+//
+//	dir := os.TempDir()
+//	d, err := execx.Command("sleep", "1").Daemonize(execx.WithDaemonPidFile(dir + "/execx-example.pid"))
+//	fmt.Println(err == nil && d.Pid > 0)
+//	// #bool true
+func WithDaemonPidFile(path string) DaemonizeOption {
+	return func(cfg *daemonConfig) { cfg.pidFile = path }
+}
+
+// Daemon describes a detached background process started by Daemonize.
+// It is deliberately a separate type from Process: a daemon has been
+// reparented away from this process (under init on Unix, with no
+// parent tracking to begin with under Windows' DETACHED_PROCESS), so
+// none of Process's pipeline-bound operations (Terminate, Send, Resize,
+// stdio access, ...) have anything to act on.
+type Daemon struct {
+	// Pid is the daemon's process ID. Signal it via os.FindProcess, or
+	// track it by the PID file set with WithDaemonPidFile.
+	Pid int
+
+	done   chan struct{}
+	result Result
+}
+
+// Wait blocks until the readiness handshake carried over the daemon's
+// status pipe resolves, then reports the outcome. By the time Daemonize
+// returns a *Daemon, that handshake has already completed, so Wait never
+// actually blocks; it exists so callers have a uniform way to check the
+// outcome without touching the unexported fields it reads from. The
+// Result it returns describes the handshake, not the daemon's eventual
+// exit: ExitCode is 0 and Err is nil when the daemon started, or Err is
+// non-nil with ExitCode set to the conventional 127/126 startup-failure
+// code (see classifyStartErr) when it didn't. The daemon is not waitable
+// beyond that; this process is never notified when it eventually exits.
+// @group Process
+//
+// Example: daemon wait
+//
+//	d, _ := execx.Command("sleep", "1").Daemonize()
+//	res, err := d.Wait()
+//	fmt.Println(err == nil && res.ExitCode == 0)
+//	// #bool true
+func (d *Daemon) Wait() (Result, error) {
+	<-d.done
+	return d.result, d.result.Err
+}
+
+// Daemonize starts the command fully detached from this process: on
+// Unix it double-forks so the daemon is reparented to init, survives
+// this process exiting, and can never reacquire a controlling terminal;
+// on Windows it falls back to DETACHED_PROCESS|CREATE_NEW_PROCESS_GROUP,
+// since there's no fork/session equivalent to degrade to there. Either
+// way, it blocks on a pipe-based handshake that the daemon side reports
+// a single status byte over before closing, so Daemonize only returns
+// once the daemon has actually started (or definitively failed to).
+// Unlike Start, the returned Daemon has no stdio or exit-status Wait; it
+// outlives this process the way WithFifoIO's named pipes let a process
+// survive an execx restart. Configure its working directory, umask,
+// stdio redirection, and PID file with DaemonizeOptions.
+// @group Process
+//
+// Example: daemonize
+//
+//	d, err := execx.Command("sleep", "1").Daemonize()
+//	fmt.Println(err == nil && d.Pid > 0)
+//	// #bool true
+func (c *Cmd) Daemonize(opts ...DaemonizeOption) (*Daemon, error) {
+	cfg := newDaemonConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return daemonize(c.rootCmd(), cfg)
+}
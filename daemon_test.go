@@ -0,0 +1,123 @@
+package execx
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestDaemonize(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a unix-only helper process signal check")
+	}
+	d, err := helperCommand("sleep", "2000").Daemonize()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if d.Pid <= 0 {
+		t.Fatalf("expected a positive pid, got %d", d.Pid)
+	}
+	defer func() {
+		proc, err := os.FindProcess(d.Pid)
+		if err == nil {
+			_ = proc.Signal(syscall.SIGKILL)
+		}
+	}()
+
+	res, err := d.Wait()
+	if err != nil || res.ExitCode != 0 {
+		t.Fatalf("expected a clean handshake, got res=%+v err=%v", res, err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	proc, err := os.FindProcess(d.Pid)
+	if err != nil {
+		t.Fatalf("expected to find daemon process, got %v", err)
+	}
+	if err := proc.Signal(syscall.Signal(0)); err != nil {
+		t.Fatalf("expected daemon process to be running, got %v", err)
+	}
+}
+
+func TestDaemonizeOptions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses unix-only dir/umask semantics")
+	}
+	dir := t.TempDir()
+	stdout := filepath.Join(dir, "stdout.log")
+	pidFile := filepath.Join(dir, "daemon.pid")
+
+	d, err := helperCommand("echo", "hi").Daemonize(
+		WithDaemonDir(dir),
+		WithDaemonStdout(stdout),
+		WithDaemonPidFile(pidFile),
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer func() {
+		proc, err := os.FindProcess(d.Pid)
+		if err == nil {
+			_ = proc.Signal(syscall.SIGKILL)
+		}
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	var out []byte
+	for time.Now().Before(deadline) {
+		out, err = os.ReadFile(stdout)
+		if err == nil && len(out) > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := strings.TrimSpace(string(out)); got != "hi" {
+		t.Fatalf("expected redirected stdout %q, got %q (err=%v)", "hi", got, err)
+	}
+
+	pidBytes, err := os.ReadFile(pidFile)
+	if err != nil {
+		t.Fatalf("expected pidfile to exist: %v", err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(pidBytes)))
+	if err != nil || pid != d.Pid {
+		t.Fatalf("expected pidfile to contain %d, got %q", d.Pid, pidBytes)
+	}
+}
+
+func TestDaemonizeCommandNotFound(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses the unix pre-flight status byte, covered by daemonizeFailed on windows")
+	}
+	d, err := Command("execx-definitely-not-a-real-binary").Daemonize()
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if d.result.ExitCode != 127 {
+		t.Fatalf("expected conventional exit code 127, got %d", d.result.ExitCode)
+	}
+}
+
+func TestDaemonizeWindows(t *testing.T) {
+	if runtime.GOOS != "windows" {
+		t.Skip("DETACHED_PROCESS fallback is windows-only")
+	}
+	d, err := helperCommand("sleep", "2000").Daemonize()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if d.Pid <= 0 {
+		t.Fatalf("expected a positive pid, got %d", d.Pid)
+	}
+	proc, err := os.FindProcess(d.Pid)
+	if err != nil {
+		t.Fatalf("expected to find daemon process, got %v", err)
+	}
+	defer func() { _ = proc.Kill() }()
+}
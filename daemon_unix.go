@@ -0,0 +1,152 @@
+//go:build unix
+
+package execx
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// daemonizeScript runs, in order: chdir to the configured dir, set the
+// configured umask, a pre-flight check that $0 is actually runnable (the
+// closest a plain /bin/sh can get to observing whether the backgrounded
+// exec below will succeed, short of the exec itself), then backgrounds
+// $0 "$@" with its stdio redirected to the configured paths. Whichever
+// branch it takes, it reports a status byte over fd 3 first, before
+// anything else: "0" once the daemon is actually backgrounded (followed
+// by its pid on a second line), "1" if $0 isn't found, "2" if it's found
+// but not executable, "3" if the chdir itself failed (neither of the
+// latter three is followed by a pid line, since backgrounding never
+// happened). Backgrounding (rather than exec'ing $0 directly in this
+// shell) is the second fork of the classic double-fork idiom: the new
+// process is a child of this shell, not a session leader, and once this
+// shell exits right after writing its status it gets reparented to init
+// instead of staying attached to anything of ours.
+const daemonizeScript = `
+cd "$EXECX_DAEMON_DIR" || { printf '3' >&3; exec 3>&-; exit 1; }
+umask "$EXECX_DAEMON_UMASK"
+if [ ! -e "$0" ] && ! command -v -- "$0" >/dev/null 2>&1; then
+	printf '1' >&3
+	exec 3>&-
+	exit 1
+fi
+if [ -e "$0" ] && [ ! -x "$0" ]; then
+	printf '2' >&3
+	exec 3>&-
+	exit 1
+fi
+stdin=$EXECX_DAEMON_STDIN
+stdout=$EXECX_DAEMON_STDOUT
+stderr=$EXECX_DAEMON_STDERR
+unset EXECX_DAEMON_DIR EXECX_DAEMON_UMASK EXECX_DAEMON_STDIN EXECX_DAEMON_STDOUT EXECX_DAEMON_STDERR
+"$0" "$@" <"$stdin" >"$stdout" 2>"$stderr" &
+cpid=$!
+printf '0' >&3
+echo "$cpid" >&3
+exec 3>&-
+`
+
+// daemonize performs the setsid-plus-background double fork described on
+// daemonizeScript, blocking on the fd-3 handshake until it knows whether
+// the daemon actually started.
+func daemonize(c *Cmd, cfg daemonConfig) (*Daemon, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("execx: daemonize: %w", err)
+	}
+	defer r.Close()
+
+	name, args := c.name, c.args
+	if c.limits != nil && limitsSupported() == nil {
+		name, args = wrapForLimits(name, args, *c.limits)
+	}
+
+	launch := exec.Command("setsid", append([]string{"sh", "-c", daemonizeScript, name}, args...)...)
+	if c.dir != "" {
+		launch.Dir = c.dir
+	}
+	launch.Env = append(buildEnv(c.envMode, c.env, c.envAllow, c.envDeny),
+		"EXECX_DAEMON_DIR="+cfg.dir,
+		"EXECX_DAEMON_UMASK="+strconv.FormatInt(int64(cfg.umask), 8),
+		"EXECX_DAEMON_STDIN="+cfg.stdin,
+		"EXECX_DAEMON_STDOUT="+cfg.stdout,
+		"EXECX_DAEMON_STDERR="+cfg.stderr,
+	)
+	launch.ExtraFiles = []*os.File{w}
+
+	if err := launch.Start(); err != nil {
+		w.Close()
+		return nil, fmt.Errorf("execx: daemonize: %w", err)
+	}
+	w.Close()
+	registerOwnedPid(launch.Process.Pid)
+	defer unregisterOwnedPid(launch.Process.Pid)
+
+	// The launcher's own exit status isn't useful here: every failure
+	// branch of daemonizeScript deliberately exits 1 after reporting its
+	// status byte, so a launch.Wait error is expected, not exceptional.
+	// What matters is what daemonizeScript wrote to fd 3 before exiting.
+	waitErr := launch.Wait()
+
+	br := bufio.NewReader(r)
+	status, err := br.ReadByte()
+	if err != nil {
+		if waitErr != nil {
+			return nil, fmt.Errorf("execx: daemonize: readiness handshake: %w", waitErr)
+		}
+		return nil, fmt.Errorf("execx: daemonize: readiness handshake: %w", err)
+	}
+
+	d := &Daemon{done: make(chan struct{})}
+	d.result = daemonResultFromStatus(status)
+	if d.result.Err != nil {
+		close(d.done)
+		return d, d.result.Err
+	}
+
+	line, err := br.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("execx: daemonize: readiness handshake: %w", err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(line))
+	if err != nil {
+		return nil, fmt.Errorf("execx: daemonize: parse pid: %w", err)
+	}
+	d.Pid = pid
+	close(d.done)
+
+	if cfg.pidFile != "" {
+		if err := os.WriteFile(cfg.pidFile, []byte(strconv.Itoa(pid)+"\n"), 0o644); err != nil {
+			return d, fmt.Errorf("execx: daemonize: write pidfile: %w", err)
+		}
+	}
+	return d, nil
+}
+
+// daemonResultFromStatus turns daemonizeScript's single status byte into
+// the Result Daemon.Wait reports.
+func daemonResultFromStatus(status byte) Result {
+	var class ExitClass
+	var msg string
+	switch status {
+	case '0':
+		return Result{ExitCode: 0}
+	case '1':
+		class, msg = ClassNotFound, "command not found"
+	case '2':
+		class, msg = ClassPermission, "permission denied"
+	default:
+		class, msg = ClassStartupFailure, "failed to chdir"
+	}
+	return Result{
+		ExitCode: startupExitCode(class),
+		Err: ErrExec{
+			Err:   fmt.Errorf("execx: daemonize: %s", msg),
+			Class: class,
+		},
+	}
+}
@@ -0,0 +1,84 @@
+//go:build windows
+
+package execx
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+// detachedProcess is CREATE_NO_WINDOW's sibling DETACHED_PROCESS flag:
+// the new process gets no console of its own and doesn't inherit ours.
+// Windows has no fork/session equivalent to build a double fork out of,
+// so this plus CREATE_NEW_PROCESS_GROUP (already in syscall) is the
+// platform's standard substitute: no controlling console to detach
+// from, and no console-control-event group to be torn down alongside
+// this process's own.
+const detachedProcess = 0x00000008
+
+// daemonize starts the command directly (no fork chain, since Windows
+// has none) with creation flags that detach it from this process's
+// console and process group, then reports the outcome as if it had gone
+// through the pipe handshake Unix uses: Start itself either succeeds or
+// fails synchronously here, so there's nothing to actually wait on.
+func daemonize(c *Cmd, cfg daemonConfig) (*Daemon, error) {
+	name, args := c.name, c.args
+	if c.limits != nil && limitsSupported() == nil {
+		name, args = wrapForLimits(name, args, *c.limits)
+	}
+
+	launch := exec.Command(name, args...)
+	launch.Dir = cfg.dir
+	launch.Env = buildEnv(c.envMode, c.env, c.envAllow, c.envDeny)
+	launch.SysProcAttr = &syscall.SysProcAttr{
+		CreationFlags: detachedProcess | syscall.CREATE_NEW_PROCESS_GROUP,
+	}
+
+	stdin, err := os.Open(cfg.stdin)
+	if err != nil {
+		return daemonizeFailed(err)
+	}
+	defer stdin.Close()
+	stdout, err := os.OpenFile(cfg.stdout, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return daemonizeFailed(err)
+	}
+	defer stdout.Close()
+	stderr, err := os.OpenFile(cfg.stderr, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return daemonizeFailed(err)
+	}
+	defer stderr.Close()
+	launch.Stdin, launch.Stdout, launch.Stderr = stdin, stdout, stderr
+
+	if err := launch.Start(); err != nil {
+		return daemonizeFailed(err)
+	}
+
+	d := &Daemon{Pid: launch.Process.Pid, done: make(chan struct{}), result: Result{ExitCode: 0}}
+	close(d.done)
+
+	if cfg.pidFile != "" {
+		if err := os.WriteFile(cfg.pidFile, []byte(strconv.Itoa(d.Pid)+"\n"), 0o644); err != nil {
+			return d, fmt.Errorf("execx: daemonize: write pidfile: %w", err)
+		}
+	}
+	return d, nil
+}
+
+// daemonizeFailed classifies a synchronous Start failure the same way
+// Unix's daemonizeScript classifies its pre-flight check, so callers get
+// the same ClassNotFound/ClassPermission distinction on both platforms.
+func daemonizeFailed(err error) (*Daemon, error) {
+	class := classifyStartErr(err)
+	result := Result{
+		ExitCode: startupExitCode(class),
+		Err:      ErrExec{Err: fmt.Errorf("execx: daemonize: %w", err), Class: class},
+	}
+	d := &Daemon{done: make(chan struct{}), result: result}
+	close(d.done)
+	return d, result.Err
+}
@@ -8,6 +8,10 @@ type ErrExec struct {
 	ExitCode int
 	Signal   os.Signal
 	Stderr   string
+
+	// Class categorizes why the command failed to start. Zero
+	// (ClassOK) for ErrExec values built before classification existed.
+	Class ExitClass
 }
 
 // Error returns the wrapped error message when available.
@@ -36,3 +40,24 @@ func (e ErrExec) Error() string {
 func (e ErrExec) Unwrap() error {
 	return e.Err
 }
+
+// Is lets errors.Is(err, execx.ErrNotFound) and
+// errors.Is(err, execx.ErrPermission) match an ErrExec by its
+// classification rather than by comparing the wrapped error directly.
+// @group Errors
+//
+// Example: is not found
+//
+//	err := execx.ErrExec{Err: fmt.Errorf("boom"), Class: execx.ClassNotFound}
+//	fmt.Println(errors.Is(err, execx.ErrNotFound))
+//	// #bool true
+func (e ErrExec) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.Class == ClassNotFound
+	case ErrPermission:
+		return e.Class == ClassPermission
+	default:
+		return false
+	}
+}
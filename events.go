@@ -0,0 +1,316 @@
+package execx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// EventKind identifies the origin of a captured Event.
+type EventKind string
+
+const (
+	// EventBefore marks a stage about to start, before its process exists.
+	EventBefore EventKind = "before"
+	// EventStarted marks a stage's process successfully starting.
+	EventStarted EventKind = "started"
+	// EventStdout marks a chunk of bytes read from stdout.
+	EventStdout EventKind = "stdout"
+	// EventStderr marks a chunk of bytes read from stderr.
+	EventStderr EventKind = "stderr"
+	// EventSignal marks a signal being delivered to a stage's process.
+	EventSignal EventKind = "signal"
+	// EventExit marks the process exiting normally.
+	EventExit EventKind = "exit"
+	// EventKill marks the process being terminated before it exited on its own.
+	EventKill EventKind = "kill"
+	// EventPipelineDone marks every stage of the pipeline having drained.
+	EventPipelineDone EventKind = "pipeline_done"
+)
+
+// Event is a single timestamped occurrence captured by CaptureEvents or
+// delivered on the channel returned by Events. Every Event carries Kind,
+// Stage, Message, and Delay; Pid is populated for EventStarted and Results
+// for EventPipelineDone, which uses Stage -1 since it isn't tied to one
+// stage.
+type Event struct {
+	Kind    EventKind
+	Stage   int
+	Message string
+	Delay   time.Duration
+	Pid     int
+	Results []Result
+}
+
+// eventSink records events from every pipe of a single stage in arrival
+// order, preserving true stdout/stderr interleaving instead of the
+// capture-then-concatenate model used by Stdout/Stderr.
+type eventSink struct {
+	mu         sync.Mutex
+	start      time.Time
+	stageIndex int
+	events     []Event
+	onEvent    func(Event)
+	channel    *eventChannel
+	sinks      []EventSink
+}
+
+func (s *eventSink) record(kind EventKind, msg string) {
+	s.emit(Event{Kind: kind, Message: msg})
+}
+
+func (s *eventSink) emit(ev Event) {
+	s.mu.Lock()
+	ev.Stage = s.stageIndex
+	ev.Delay = time.Since(s.start)
+	s.events = append(s.events, ev)
+	onEvent := s.onEvent
+	channel := s.channel
+	sinks := s.sinks
+	s.mu.Unlock()
+	if onEvent != nil {
+		onEvent(ev)
+	}
+	if channel != nil {
+		channel.send(ev)
+	}
+	// Sink errors are swallowed, the same way OnEvent can't fail a run:
+	// observability plumbing must never break the command it's watching.
+	for _, sink := range sinks {
+		_ = sink.Emit(ev)
+	}
+}
+
+func (s *eventSink) snapshot() []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Event{}, s.events...)
+}
+
+type eventWriter struct {
+	sink *eventSink
+	kind EventKind
+}
+
+func (w *eventWriter) Write(p []byte) (int, error) {
+	if len(p) > 0 {
+		w.sink.record(w.kind, string(p))
+	}
+	return len(p), nil
+}
+
+// CaptureEvents enables ordered, timestamped event capture for this
+// command's stdout/stderr, available on Result.Events after it runs.
+// @group Streaming
+//
+// Example: capture events
+//
+//	res, _ := execx.Command("go", "env", "GOOS").CaptureEvents().Run()
+//	fmt.Println(len(res.Events) > 0)
+//	// #bool true
+func (c *Cmd) CaptureEvents() *Cmd {
+	c.ensureEvents()
+	return c
+}
+
+// OnEvent registers a callback invoked as each stdout/stderr/exit event is
+// recorded, enabling live playback of interleaved output.
+// @group Streaming
+//
+// Example: on event
+//
+//	var kinds []execx.EventKind
+//	_, _ = execx.Command("go", "env", "GOOS").
+//		OnEvent(func(ev execx.Event) { kinds = append(kinds, ev.Kind) }).
+//		Run()
+//	fmt.Println(len(kinds) > 0)
+//	// #bool true
+func (c *Cmd) OnEvent(fn func(Event)) *Cmd {
+	c.ensureEvents().onEvent = fn
+	return c
+}
+
+// WithEventSink registers a pluggable EventSink that receives every
+// lifecycle and IO event for this command's whole pipeline, in the same
+// arrival order as Events and OnEvent. Multiple sinks can be registered;
+// each receives every event. Call it before Run/Start so every stage is
+// wired up before the pipeline launches.
+// @group Streaming
+//
+// Example: with event sink
+//
+//	var buf bytes.Buffer
+//	_, _ = execx.Command("go", "env", "GOOS").
+//		WithEventSink(execx.NewJSONLinesEventSink(&buf)).
+//		Run()
+//	fmt.Println(buf.Len() > 0)
+//	// #bool true
+func (c *Cmd) WithEventSink(sink EventSink) *Cmd {
+	root := c.rootCmd()
+	root.eventSinks = append(root.eventSinks, sink)
+	return c
+}
+
+func (c *Cmd) ensureEvents() *eventSink {
+	if c.events == nil {
+		c.events = &eventSink{}
+	}
+	return c.events
+}
+
+// defaultEventsBuffer is the channel capacity Events uses when
+// EventsBuffered hasn't been called.
+const defaultEventsBuffer = 64
+
+// eventChannel fans every stage's events into a single channel shared by a
+// whole pipeline, applying backpressure policy on a full buffer instead of
+// blocking the process that's producing events.
+type eventChannel struct {
+	mu         sync.Mutex
+	ch         chan Event
+	dropOldest bool
+	closed     bool
+}
+
+func newEventChannel(buffer int, dropOldest bool) *eventChannel {
+	if buffer <= 0 {
+		buffer = defaultEventsBuffer
+	}
+	return &eventChannel{ch: make(chan Event, buffer), dropOldest: dropOldest}
+}
+
+func (e *eventChannel) send(ev Event) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return
+	}
+	select {
+	case e.ch <- ev:
+		return
+	default:
+	}
+	if !e.dropOldest {
+		// Default policy: drop the newest event rather than block.
+		return
+	}
+	select {
+	case <-e.ch:
+	default:
+	}
+	select {
+	case e.ch <- ev:
+	default:
+	}
+}
+
+func (e *eventChannel) close() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.closed {
+		close(e.ch)
+		e.closed = true
+	}
+}
+
+// EventsBuffered sets the buffer size of the channel returned by Events.
+// It has no effect once Events has already been called.
+// @group Streaming
+//
+// Example: events buffered
+//
+//	cmd := execx.Command("go", "env", "GOOS").EventsBuffered(256)
+//	ch := cmd.Events()
+//	_, _ = cmd.Run()
+//	fmt.Println(cap(ch) == 256)
+//	// #bool true
+func (c *Cmd) EventsBuffered(n int) *Cmd {
+	c.rootCmd().eventsBuf = n
+	return c
+}
+
+// EventsDropOldest makes a full Events channel drop its oldest buffered
+// event to make room for a new one, instead of dropping the new event. It
+// has no effect once Events has already been called.
+// @group Streaming
+//
+// Example: events drop oldest
+//
+//	cmd := execx.Command("go", "env", "GOOS").EventsDropOldest()
+//	cmd.Events()
+//	_, err := cmd.Run()
+//	fmt.Println(err == nil)
+//	// #bool true
+func (c *Cmd) EventsDropOldest() *Cmd {
+	c.rootCmd().eventsDropOldest = true
+	return c
+}
+
+// Events returns a channel that receives every lifecycle and IO event for
+// this command (and every stage of its pipeline, distinguished by
+// Event.Stage) in arrival order, alongside whatever CaptureEvents or
+// OnEvent already deliver. It's closed exactly once, after an
+// EventPipelineDone event, when the pipeline fully drains. Call it before
+// Run/Start so every stage is wired up before the pipeline launches.
+// @group Streaming
+//
+// Example: events
+//
+//	cmd := execx.Command("go", "env", "GOOS")
+//	ch := cmd.Events()
+//	var kinds []execx.EventKind
+//	done := make(chan struct{})
+//	go func() {
+//		for ev := range ch {
+//			kinds = append(kinds, ev.Kind)
+//		}
+//		close(done)
+//	}()
+//	_, _ = cmd.Run()
+//	<-done
+//	fmt.Println(len(kinds) > 0)
+//	// #bool true
+func (c *Cmd) Events() <-chan Event {
+	root := c.rootCmd()
+	if root.eventsChan == nil {
+		root.eventsChan = newEventChannel(root.eventsBuf, root.eventsDropOldest)
+	}
+	return root.eventsChan.ch
+}
+
+// Events mirrors Cmd.Events for an already-started Process: it returns the
+// channel the originating Cmd's Events call created, or nil if Events was
+// never called before Start.
+// @group Streaming
+//
+// Example: process events
+//
+//	cmd := execx.Command("go", "env", "GOOS")
+//	cmd.Events()
+//	proc := cmd.Start()
+//	_, _ = proc.Wait()
+//	fmt.Println(proc.Events() != nil)
+//	// #bool true
+func (p *Process) Events() <-chan Event {
+	return p.events
+}
+
+func (s *stage) recordExitEvent(res *Result) {
+	sink := s.def.events
+	if sink == nil {
+		return
+	}
+	switch {
+	case errors.Is(res.Err, context.DeadlineExceeded):
+		sink.record(EventKill, "timeout")
+	case errors.Is(res.Err, context.Canceled):
+		sink.record(EventKill, "canceled")
+	case res.signal != nil:
+		sink.record(EventKill, fmt.Sprintf("signal: %s", res.signal))
+	default:
+		sink.record(EventExit, fmt.Sprintf("exit %d", res.ExitCode))
+	}
+	res.Events = sink.snapshot()
+}
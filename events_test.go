@@ -0,0 +1,210 @@
+package execx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCaptureEvents(t *testing.T) {
+	res, err := helperCommand("lines").CaptureEvents().Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(res.Events) == 0 {
+		t.Fatalf("expected events to be recorded")
+	}
+	var sawStdout, sawStderr, sawExit bool
+	for _, ev := range res.Events {
+		switch ev.Kind {
+		case EventStdout:
+			sawStdout = true
+		case EventStderr:
+			sawStderr = true
+		case EventExit:
+			sawExit = true
+		}
+	}
+	if !sawStdout || !sawStderr || !sawExit {
+		t.Fatalf("expected stdout, stderr and exit events, got %+v", res.Events)
+	}
+}
+
+func TestOnEvent(t *testing.T) {
+	var kinds []EventKind
+	_, err := helperCommand("echo", "hi").OnEvent(func(ev Event) {
+		kinds = append(kinds, ev.Kind)
+	}).Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(kinds) == 0 {
+		t.Fatalf("expected OnEvent to fire")
+	}
+}
+
+func TestEventsChannel(t *testing.T) {
+	cmd := helperCommand("echo", "hi")
+	ch := cmd.Events()
+	var events []Event
+	done := make(chan struct{})
+	go func() {
+		for ev := range ch {
+			events = append(events, ev)
+		}
+		close(done)
+	}()
+
+	if _, err := cmd.Run(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	<-done
+
+	var sawStarted, sawExit, sawDone bool
+	for _, ev := range events {
+		switch ev.Kind {
+		case EventStarted:
+			sawStarted = true
+			if ev.Pid == 0 {
+				t.Fatalf("expected EventStarted to carry a pid")
+			}
+		case EventExit:
+			sawExit = true
+		case EventPipelineDone:
+			sawDone = true
+			if ev.Stage != -1 {
+				t.Fatalf("expected EventPipelineDone to use stage -1, got %d", ev.Stage)
+			}
+		}
+	}
+	if !sawStarted || !sawExit || !sawDone {
+		t.Fatalf("expected started, exit and pipeline done events, got %+v", events)
+	}
+}
+
+func TestEventsPipelineStages(t *testing.T) {
+	cmd := helperCommand("echo", "hi").Pipe("cat")
+	ch := cmd.Events()
+	var events []Event
+	done := make(chan struct{})
+	go func() {
+		for ev := range ch {
+			events = append(events, ev)
+		}
+		close(done)
+	}()
+
+	if _, err := cmd.PipelineResults(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	<-done
+
+	stages := map[int]bool{}
+	for _, ev := range events {
+		if ev.Kind != EventPipelineDone {
+			stages[ev.Stage] = true
+		}
+	}
+	if !stages[0] || !stages[1] {
+		t.Fatalf("expected events from both stages, got %+v", stages)
+	}
+}
+
+func TestEventsDropOldestNoDeadlock(t *testing.T) {
+	cmd := helperCommand("lines").EventsBuffered(1).EventsDropOldest()
+	ch := cmd.Events()
+
+	if _, err := cmd.Run(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	drained := 0
+	for range ch {
+		drained++
+	}
+	if drained == 0 {
+		t.Fatalf("expected at least one event to survive backpressure")
+	}
+}
+
+func TestProcessEvents(t *testing.T) {
+	cmd := helperCommand("sleep", "0")
+	cmd.Events()
+	proc := cmd.Start()
+	if proc.Events() == nil {
+		t.Fatalf("expected Process.Events to mirror the Cmd channel")
+	}
+	if _, err := proc.Wait(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for range proc.Events() {
+	}
+}
+
+func TestWithEventSinkJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewJSONLinesEventSink(&buf)
+	_, err := helperCommand("lines").WithEventSink(sink).Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) == 0 || lines[0] == "" {
+		t.Fatalf("expected at least one JSON line, got %q", buf.String())
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, `"kind"`) {
+			t.Fatalf("expected each line to be a JSON event, got %q", line)
+		}
+	}
+}
+
+func TestWithEventSinkFramed(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewFramedEventSink(&buf)
+	_, err := helperCommand("echo", "hi").WithEventSink(sink).Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if buf.Len() == 0 {
+		t.Fatalf("expected framed sink to write bytes")
+	}
+}
+
+func TestWithEventSinkChannel(t *testing.T) {
+	ch := make(chan Event, 64)
+	sink := NewChannelEventSink(ch, false)
+	_, err := helperCommand("echo", "hi").WithEventSink(sink).Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	close(ch)
+	var kinds []EventKind
+	for ev := range ch {
+		kinds = append(kinds, ev.Kind)
+	}
+	if len(kinds) == 0 {
+		t.Fatalf("expected ChannelEventSink to deliver events")
+	}
+}
+
+func TestWithEventSinkMultiplePipelineStages(t *testing.T) {
+	sink := NewChannelEventSink(make(chan Event, 256), false)
+	_, err := helperCommand("echo", "hi").Pipe("cat").WithEventSink(sink).PipelineResults()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestEventBefore(t *testing.T) {
+	var kinds []EventKind
+	_, err := helperCommand("echo", "hi").OnEvent(func(ev Event) {
+		kinds = append(kinds, ev.Kind)
+	}).Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if kinds[0] != EventBefore {
+		t.Fatalf("expected the first recorded event to be EventBefore, got %+v", kinds)
+	}
+}
@@ -0,0 +1,160 @@
+package execx
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/goforj/execx/internal/wire"
+)
+
+// EventSink receives every event recorded for a command's pipeline, in
+// arrival order, as an alternative to OnEvent or Events for plugging execx
+// into an observability system (a log shipper, an otel exporter, an audit
+// trail) instead of scraping stdout. Register one with WithEventSink.
+type EventSink interface {
+	Emit(ev Event) error
+}
+
+// jsonEvent is the wire shape Event is marshaled to by JSONLinesEventSink
+// and FramedEventSink: EventKind and time.Duration don't have a JSON
+// representation worth keeping stable across those sinks without pinning
+// it down explicitly.
+type jsonEvent struct {
+	Kind      EventKind `json:"kind"`
+	Stage     int       `json:"stage"`
+	Message   string    `json:"message"`
+	DelayNS   int64     `json:"delay_ns"`
+	Pid       int       `json:"pid,omitempty"`
+	ResultLen int       `json:"results,omitempty"`
+}
+
+func toJSONEvent(ev Event) jsonEvent {
+	return jsonEvent{
+		Kind:      ev.Kind,
+		Stage:     ev.Stage,
+		Message:   ev.Message,
+		DelayNS:   int64(ev.Delay),
+		Pid:       ev.Pid,
+		ResultLen: len(ev.Results),
+	}
+}
+
+// JSONLinesEventSink writes one JSON object per line, the format most log
+// shippers (loki, journald forwarders) ingest without extra parsing rules.
+type JSONLinesEventSink struct {
+	mu sync.Mutex
+	w  *bufio.Writer
+}
+
+// NewJSONLinesEventSink wraps w so every emitted Event becomes one JSON
+// object terminated by a newline.
+// @group Streaming
+//
+// Example: new json lines event sink
+//
+//	var buf bytes.Buffer
+//	sink := execx.NewJSONLinesEventSink(&buf)
+//	_, _ = execx.Command("go", "env", "GOOS").WithEventSink(sink).Run()
+//	fmt.Println(strings.Count(buf.String(), "\n") > 0)
+//	// #bool true
+func NewJSONLinesEventSink(w io.Writer) *JSONLinesEventSink {
+	return &JSONLinesEventSink{w: bufio.NewWriter(w)}
+}
+
+// Emit implements EventSink.
+func (s *JSONLinesEventSink) Emit(ev Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := json.NewEncoder(s.w).Encode(toJSONEvent(ev)); err != nil {
+		return err
+	}
+	return s.w.Flush()
+}
+
+// FramedEventSink writes each Event as a length-prefixed binary frame, the
+// same framing execx's remote transport uses in place of gRPC/protobuf to
+// stay dependency-free, for sinks that need message boundaries without
+// scanning for newlines (e.g. a raw TCP forwarder).
+type FramedEventSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewFramedEventSink wraps w so every emitted Event becomes one
+// length-prefixed frame written via the wire package's framing.
+// @group Streaming
+//
+// Example: new framed event sink
+//
+//	var buf bytes.Buffer
+//	sink := execx.NewFramedEventSink(&buf)
+//	_, _ = execx.Command("go", "env", "GOOS").WithEventSink(sink).Run()
+//	fmt.Println(buf.Len() > 0)
+//	// #bool true
+func NewFramedEventSink(w io.Writer) *FramedEventSink {
+	return &FramedEventSink{w: w}
+}
+
+// Emit implements EventSink.
+func (s *FramedEventSink) Emit(ev Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return wire.WriteMessage(s.w, toJSONEvent(ev))
+}
+
+// ChannelEventSink delivers events to an in-process Go channel, for callers
+// that already have a consumption loop and don't want a second channel
+// alongside Events. dropOldest mirrors EventsDropOldest's backpressure
+// policy: by default a full channel drops the new event; with dropOldest
+// set it drops the oldest buffered one instead. Either way Emit never
+// blocks.
+type ChannelEventSink struct {
+	ch         chan Event
+	dropOldest bool
+	mu         sync.Mutex
+}
+
+// NewChannelEventSink wraps ch so every emitted Event is sent to it
+// without blocking the command producing the events.
+// @group Streaming
+//
+// Example: new channel event sink
+//
+//	ch := make(chan execx.Event, 16)
+//	sink := execx.NewChannelEventSink(ch, false)
+//	_, _ = execx.Command("go", "env", "GOOS").WithEventSink(sink).Run()
+//	close(ch)
+//	n := 0
+//	for range ch {
+//		n++
+//	}
+//	fmt.Println(n > 0)
+//	// #bool true
+func NewChannelEventSink(ch chan Event, dropOldest bool) *ChannelEventSink {
+	return &ChannelEventSink{ch: ch, dropOldest: dropOldest}
+}
+
+// Emit implements EventSink.
+func (s *ChannelEventSink) Emit(ev Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case s.ch <- ev:
+		return nil
+	default:
+	}
+	if !s.dropOldest {
+		return nil
+	}
+	select {
+	case <-s.ch:
+	default:
+	}
+	select {
+	case s.ch <- ev:
+	default:
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// Add registers factory as a managed command under policy and backoff,
+	// returning an id that Stop and Status use to refer back to it.
+
+	// Example: add
+	sup := execx.NewSupervisor()
+	id := sup.Add(func() *execx.Cmd { return execx.Command("go", "env", "GOOS") },
+		execx.RestartNever, execx.Backoff{})
+	fmt.Println(id != "")
+	// #bool true
+}
@@ -0,0 +1,24 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// AddStderrSink registers a named destination for this command's
+	// stderr. See AddStdoutSink for the available options.
+
+	// Example: add stderr sink
+	var out strings.Builder
+	execx.Command("go", "env", "-badflag").
+		AddStderrSink("log", &out).
+		Run()
+	fmt.Println(out.Len() > 0)
+	// #bool true
+}
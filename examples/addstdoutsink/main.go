@@ -0,0 +1,25 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// AddStdoutSink registers a named destination for this command's
+	// stdout, independent of StdoutWriter, OnStdout, Tee, and any other
+	// sinks already registered.
+
+	// Example: add stdout sink
+	var out strings.Builder
+	execx.Command("go", "env", "GOOS").
+		AddStdoutSink("log", &out).
+		Run()
+	fmt.Println(out.Len() > 0)
+	// #bool true
+}
@@ -0,0 +1,19 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// CacheNonZero allows results with a non-zero exit code to be cached.
+
+	// Example: cache non zero
+	cmd := execx.Command("false").WithCache(execx.NewMemoryCache(8)).CacheNonZero()
+	fmt.Println(cmd != nil)
+	// #bool true
+}
@@ -0,0 +1,19 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// CaptureEvents enables ordered, timestamped event capture for this
+	// command's stdout/stderr, available on Result.Events after it runs.
+
+	// Example: capture events
+	res, _ := execx.Command("go", "env", "GOOS").CaptureEvents().Run()
+	fmt.Println(len(res.Events) > 0)
+	// #bool true
+}
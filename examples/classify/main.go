@@ -0,0 +1,20 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// Classify reports how this result's exit code should be
+	// interpreted.
+
+	// Example: classify
+	res, _ := execx.Command("go", "env", "GOOS").Run()
+	fmt.Println(res.Classify() == execx.ClassOK)
+	// #bool true
+}
@@ -0,0 +1,23 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// CommandOn constructs a new command that executes against dialer
+	// instead of the local host, while supporting the same builder API
+	// as Command.
+
+	// Example: command on
+	dialer := execx.Remote("tcp://127.0.0.1:9123")
+	cmd := execx.CommandOn(dialer, "go", "env", "GOOS")
+	fmt.Println(strings.Join(cmd.Args(), " "))
+	// #string go env GOOS
+}
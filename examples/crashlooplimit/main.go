@@ -0,0 +1,22 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// WithCrashLoopLimit enables the crash-loop detector: once a managed
+	// command has restarted more than limit times inside window, the
+	// supervisor stops restarting it.
+
+	// Example: crash loop limit
+	sup := execx.NewSupervisor(execx.WithCrashLoopLimit(5, time.Minute))
+	fmt.Println(sup != nil)
+	// #bool true
+}
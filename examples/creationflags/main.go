@@ -15,8 +15,4 @@ func main() {
 	cmd := execx.Command("go", "env", "GOOS").CreationFlags(0)
 	fmt.Println(cmd != nil)
 	// #bool true
-	// Example: creation flags
-	cmd := execx.Command("go", "env", "GOOS").CreationFlags(0)
-	fmt.Println(cmd != nil)
-	// #bool true
 }
@@ -0,0 +1,20 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// Daemonize starts the command fully detached from this process,
+	// double-forking so it survives this process exiting.
+
+	// Example: daemonize
+	d, err := execx.Command("sleep", "1").Daemonize()
+	fmt.Println(err == nil && d.Pid > 0)
+	// #bool true
+}
@@ -0,0 +1,20 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// EnvAllow forwards the named variables from the parent environment
+	// when HermeticEnv is in effect.
+
+	// Example: env allow
+	cmd := execx.Command("go", "env", "GOOS").HermeticEnv().EnvAllow("PATH", "HOME")
+	fmt.Println(len(cmd.EnvList()) > 0)
+	// #bool true
+}
@@ -0,0 +1,19 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// EnvDeny removes the named variables from an inherited environment.
+
+	// Example: env deny
+	cmd := execx.Command("go", "env", "GOOS").EnvDeny("GOOS")
+	fmt.Println(cmd != nil)
+	// #bool true
+}
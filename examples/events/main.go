@@ -0,0 +1,32 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// Events returns a channel that receives every lifecycle and IO event
+	// for this command in arrival order, alongside whatever CaptureEvents
+	// or OnEvent already deliver.
+
+	// Example: events
+	cmd := execx.Command("go", "env", "GOOS")
+	ch := cmd.Events()
+	var kinds []execx.EventKind
+	done := make(chan struct{})
+	go func() {
+		for ev := range ch {
+			kinds = append(kinds, ev.Kind)
+		}
+		close(done)
+	}()
+	cmd.Run()
+	<-done
+	fmt.Println(len(kinds) > 0)
+	// #bool true
+}
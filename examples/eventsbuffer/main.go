@@ -0,0 +1,20 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// WithEventsBuffer sets the buffer size of the channel returned by
+	// Events.
+
+	// Example: events buffer
+	sup := execx.NewSupervisor(execx.WithEventsBuffer(256))
+	fmt.Println(cap(sup.Events()))
+	// #int 256
+}
@@ -0,0 +1,22 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// EventsBuffered sets the buffer size of the channel returned by
+	// Events. It has no effect once Events has already been called.
+
+	// Example: events buffered
+	cmd := execx.Command("go", "env", "GOOS").EventsBuffered(256)
+	ch := cmd.Events()
+	cmd.Run()
+	fmt.Println(cap(ch) == 256)
+	// #bool true
+}
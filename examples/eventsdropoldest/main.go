@@ -0,0 +1,23 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// EventsDropOldest makes a full Events channel drop its oldest
+	// buffered event to make room for a new one, instead of dropping the
+	// new event.
+
+	// Example: events drop oldest
+	cmd := execx.Command("go", "env", "GOOS").EventsDropOldest()
+	cmd.Events()
+	_, err := cmd.Run()
+	fmt.Println(err == nil)
+	// #bool true
+}
@@ -0,0 +1,24 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// FanOut forks this command's stdout into the given branch commands,
+	// which run concurrently once the producer starts.
+
+	// Example: fan out
+	var upper strings.Builder
+	out := execx.Command("printf", "go").
+		FanOut(execx.Command("tr", "a-z", "A-Z").StdoutWriter(&upper))
+	res, _ := out.Run()
+	fmt.Println(res.OK())
+	// #bool true
+}
@@ -0,0 +1,21 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// HermeticEnv starts the command from an empty environment; only
+	// variables added with Env or forwarded with EnvAllow are present.
+
+	// Example: hermetic env
+	cmd := execx.Command("go", "env", "GOOS").HermeticEnv().EnvAllow("PATH")
+	fmt.Println(strings.Join(cmd.EnvList(), ",") != "")
+	// #bool true
+}
@@ -21,7 +21,7 @@ func main() {
 	proc := execx.Command(os.Args[0], "execx-example", "sleep").
 		Start()
 	_ = proc.Interrupt()
-	res := proc.Wait()
-	fmt.Println(res.ExitCode != 0)
+	res, err := proc.Wait()
+	fmt.Println(err != nil || res.ExitCode != 0)
 	// #bool true
 }
@@ -0,0 +1,20 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// IsCanceled reports whether the result represents a cancelled
+	// context, equivalent to Classify() == ClassCancelled.
+
+	// Example: canceled
+	res, _ := execx.Command("go", "env", "GOOS").Run()
+	fmt.Println(res.IsCanceled())
+	// #bool false
+}
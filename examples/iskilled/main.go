@@ -0,0 +1,19 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// IsKilled reports whether the command was terminated by SIGKILL.
+
+	// Example: killed
+	res, _ := execx.Command("go", "env", "GOOS").Run()
+	fmt.Println(res.IsKilled())
+	// #bool false
+}
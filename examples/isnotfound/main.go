@@ -0,0 +1,21 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// ErrExec.Is lets errors.Is(err, execx.ErrNotFound) match by
+	// classification instead of by comparing the wrapped error.
+
+	// Example: is not found
+	err := execx.ErrExec{Err: fmt.Errorf("boom"), Class: execx.ClassNotFound}
+	fmt.Println(errors.Is(err, execx.ErrNotFound))
+	// #bool true
+}
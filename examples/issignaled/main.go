@@ -0,0 +1,20 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// IsSignaled reports whether the command terminated due to any
+	// signal, equivalent to Classify() == ClassSignaled.
+
+	// Example: signaled
+	res, _ := execx.Command("go", "env", "GOOS").Run()
+	fmt.Println(res.IsSignaled())
+	// #bool false
+}
@@ -0,0 +1,21 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// IsTransient reports whether this result looks like a transient,
+	// retry-safe failure, so callers can build retry policies without
+	// decoding raw exit codes themselves.
+
+	// Example: is transient
+	res, _ := execx.Command("go", "env", "GOOS").Run()
+	fmt.Println(res.IsTransient())
+	// #bool false
+}
@@ -0,0 +1,27 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// NewChannelEventSink wraps ch so every emitted Event is sent to it
+	// without blocking the command producing the events.
+
+	// Example: new channel event sink
+	ch := make(chan execx.Event, 16)
+	sink := execx.NewChannelEventSink(ch, false)
+	execx.Command("go", "env", "GOOS").WithEventSink(sink).Run()
+	close(ch)
+	n := 0
+	for range ch {
+		n++
+	}
+	fmt.Println(n > 0)
+	// #bool true
+}
@@ -0,0 +1,23 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// NewFileCache returns a Cache backend that persists each Result as a
+	// JSON file under dir.
+
+	// Example: file cache
+	cache := execx.NewFileCache(os.TempDir())
+	cache.Put("key", execx.Result{Stdout: "hi"})
+	res, ok := cache.Get("key")
+	fmt.Println(ok && res.Stdout == "hi")
+	// #bool true
+}
@@ -0,0 +1,23 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// NewFramedEventSink wraps w so every emitted Event becomes one
+	// length-prefixed frame written via the wire package's framing.
+
+	// Example: new framed event sink
+	var buf bytes.Buffer
+	sink := execx.NewFramedEventSink(&buf)
+	execx.Command("go", "env", "GOOS").WithEventSink(sink).Run()
+	fmt.Println(buf.Len() > 0)
+	// #bool true
+}
@@ -0,0 +1,24 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// NewJSONLinesEventSink wraps w so every emitted Event becomes one
+	// JSON object terminated by a newline.
+
+	// Example: new json lines event sink
+	var buf bytes.Buffer
+	sink := execx.NewJSONLinesEventSink(&buf)
+	execx.Command("go", "env", "GOOS").WithEventSink(sink).Run()
+	fmt.Println(strings.Count(buf.String(), "\n") > 0)
+	// #bool true
+}
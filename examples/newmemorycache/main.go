@@ -0,0 +1,22 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// NewMemoryCache returns an in-memory, goroutine-safe Cache bounded to
+	// the given number of entries.
+
+	// Example: memory cache
+	cache := execx.NewMemoryCache(32)
+	cache.Put("key", execx.Result{Stdout: "hi"})
+	res, ok := cache.Get("key")
+	fmt.Println(ok && res.Stdout == "hi")
+	// #bool true
+}
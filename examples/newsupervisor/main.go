@@ -0,0 +1,20 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// NewSupervisor creates an empty Supervisor. Add commands to it, then
+	// call Start to launch them.
+
+	// Example: new supervisor
+	sup := execx.NewSupervisor()
+	fmt.Println(sup != nil)
+	// #bool true
+}
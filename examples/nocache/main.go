@@ -0,0 +1,19 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// NoCache disables result caching for this command.
+
+	// Example: no cache
+	cmd := execx.Command("go", "env", "GOOS").WithCache(execx.NewMemoryCache(8)).NoCache()
+	fmt.Println(cmd != nil)
+	// #bool true
+}
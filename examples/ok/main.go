@@ -12,7 +12,7 @@ func main() {
 	// OK reports whether the command exited cleanly without errors.
 
 	// Example: ok
-	res := execx.Command("go", "env", "GOOS").Run()
+	res, _ := execx.Command("go", "env", "GOOS").Run()
 	fmt.Println(res.OK())
 	// #bool true
 }
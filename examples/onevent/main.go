@@ -0,0 +1,22 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// OnEvent registers a callback invoked as each stdout/stderr/exit event
+	// is recorded, enabling live playback of interleaved output.
+
+	// Example: on event
+	var kinds []execx.EventKind
+	execx.Command("go", "env", "GOOS").
+		OnEvent(func(ev execx.Event) { kinds = append(kinds, ev.Kind) }).
+		Run()
+	fmt.Println(len(kinds) > 0)
+	// #bool true
+}
@@ -4,18 +4,21 @@
 package main
 
 import (
+	"fmt"
 	"os/exec"
 
 	"github.com/goforj/execx"
 )
 
 func main() {
-	// OnExecCmd registers a callback to mutate the underlying exec.Cmd before start.
+	// OnExecCmd exposes the underlying *exec.Cmd right before it starts,
+	// for configuration execx doesn't wrap directly.
 
-	// Example: exec cmd
-	_, _ = execx.Command("printf", "hi").
-		OnExecCmd(func(cmd *exec.Cmd) {
-			cmd.Env = append(cmd.Env, "EXAMPLE=1")
-		}).
+	// Example: on exec cmd
+	var sawPath string
+	res, _ := execx.Command("go", "env", "GOOS").
+		OnExecCmd(func(cmd *exec.Cmd) { sawPath = cmd.Path }).
 		Run()
+	fmt.Println(sawPath != "" && res.ExitCode == 0)
+	// #bool true
 }
@@ -0,0 +1,22 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// OnMatch runs fn for every stdout line matching pattern.
+
+	// Example: on match
+	var hits []string
+	execx.Command("go", "env", "GOOS").
+		OnMatch(`^\w+$`, func(line string, groups []string) { hits = append(hits, line) }).
+		Run()
+	fmt.Println(len(hits) > 0)
+	// #bool true
+}
@@ -0,0 +1,22 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// OnMatchStderr runs fn for every stderr line matching pattern.
+
+	// Example: on match stderr
+	var hits []string
+	execx.Command("go", "env", "-badflag").
+		OnMatchStderr(`flag provided`, func(line string, groups []string) { hits = append(hits, line) }).
+		Run()
+	fmt.Println(len(hits) > 0)
+	// #bool true
+}
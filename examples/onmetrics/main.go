@@ -0,0 +1,23 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// OnMetrics registers a callback invoked once per stage, right after
+	// that stage's process exits, with the Rusage collected for it.
+
+	// Example: on metrics
+	var usage execx.Rusage
+	_, _ = execx.Command("go", "env", "GOOS").
+		OnMetrics(func(u execx.Rusage) { usage = u }).
+		Run()
+	fmt.Println(usage.UserTime >= 0)
+	// #bool true
+}
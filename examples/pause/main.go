@@ -0,0 +1,21 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// Pause suspends every stage of the pipeline.
+
+	// Example: pause
+	proc := execx.Command("sleep", "2").Start()
+	_ = proc.Pause()
+	fmt.Println(proc.Paused())
+	_ = proc.Resume()
+	// #bool true
+}
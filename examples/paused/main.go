@@ -0,0 +1,22 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// Paused reports whether the process was most recently suspended with
+	// Pause and not since resumed with Resume.
+
+	// Example: paused
+	proc := execx.Command("sleep", "2").Start()
+	_ = proc.Pause()
+	fmt.Println(proc.Paused())
+	_ = proc.Resume()
+	// #bool true
+}
@@ -15,12 +15,4 @@ func main() {
 	cmd := execx.Command("go", "env", "GOOS").Pdeathsig(0)
 	fmt.Println(cmd != nil)
 	// #bool true
-	// Example: pdeathsig
-	cmd := execx.Command("go", "env", "GOOS").Pdeathsig(0)
-	fmt.Println(cmd != nil)
-	// #bool true
-	// Example: pdeathsig
-	cmd := execx.Command("go", "env", "GOOS").Pdeathsig(0)
-	fmt.Println(cmd != nil)
-	// #bool true
 }
@@ -23,7 +23,7 @@ func main() {
 		}
 		return
 	}
-	res := execx.Command(os.Args[0], "execx-example", "sleep").
+	res, _ := execx.Command(os.Args[0], "execx-example", "sleep").
 		WithTimeout(50 * time.Millisecond).
 		Pipe(os.Args[0], "execx-example", "ok").
 		PipeBestEffort().
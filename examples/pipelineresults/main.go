@@ -25,7 +25,7 @@ func main() {
 		}
 		return
 	}
-	results := execx.Command(os.Args[0], "execx-example", "emit").
+	results, _ := execx.Command(os.Args[0], "execx-example", "emit").
 		Pipe(os.Args[0], "execx-example", "upper").
 		PipelineResults()
 	fmt.Println(len(results))
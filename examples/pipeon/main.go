@@ -0,0 +1,22 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// PipeOn pipes this stage's stdout into a new stage that runs against
+	// a different dialer than the rest of the pipeline.
+
+	// Example: pipe on
+	dialer := execx.Remote("tcp://127.0.0.1:0")
+	cmd := execx.Command("printf", "go").PipeOn(dialer, "tr", "a-z", "A-Z")
+	fmt.Println(strings.Join(cmd.Args(), " "))
+	// #string tr a-z A-Z
+}
@@ -22,7 +22,7 @@ func main() {
 		}
 		return
 	}
-	res := execx.Command(os.Args[0], "execx-example", "fail").
+	res, _ := execx.Command(os.Args[0], "execx-example", "fail").
 		Pipe(os.Args[0], "execx-example", "ok").
 		PipeStrict().
 		Run()
@@ -0,0 +1,24 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// Events mirrors Cmd.Events for an already-started Process: it
+	// returns the channel the originating Cmd's Events call created, or
+	// nil if Events was never called before Start.
+
+	// Example: process events
+	cmd := execx.Command("go", "env", "GOOS")
+	cmd.Events()
+	proc := cmd.Start()
+	proc.Wait()
+	fmt.Println(proc.Events() != nil)
+	// #bool true
+}
@@ -0,0 +1,23 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// PTYMaster exposes the pseudo-terminal's master side once the
+	// process has started, for callers that need to read or write it
+	// directly instead of going through OnStdout.
+
+	// Example: pty master
+	proc := execx.Command("go", "env", "GOOS").WithPTY().Start()
+	master := proc.PTYMaster()
+	_, _ = proc.Wait()
+	fmt.Println(master != nil)
+	// #bool true
+}
@@ -0,0 +1,20 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// PTYRawMode puts the controlling terminal into raw mode for the
+	// duration of the command and restores it afterward.
+
+	// Example: pty raw mode
+	res, _ := execx.Command("go", "env", "GOOS").PTYRawMode().Run()
+	fmt.Println(res.ExitCode == 0)
+	// #bool true
+}
@@ -0,0 +1,20 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// PTYSize sets the terminal window size applied to the pseudo-terminal
+	// as soon as it is opened.
+
+	// Example: pty size
+	res, _ := execx.Command("go", "env", "GOOS").PTYSize(24, 80).Run()
+	fmt.Println(res.ExitCode == 0)
+	// #bool true
+}
@@ -0,0 +1,21 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// PTYStdin forwards r to the pseudo-terminal's master side for the
+	// duration of the command.
+
+	// Example: pty stdin
+	res, _ := execx.Command("go", "env", "GOOS").PTYStdin(os.Stdin).Run()
+	fmt.Println(res.ExitCode == 0)
+	// #bool true
+}
@@ -0,0 +1,19 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// Remote builds a Dialer for an execx agent listening at endpoint.
+
+	// Example: remote
+	dialer := execx.Remote("tcp://127.0.0.1:9123")
+	fmt.Println(dialer != nil)
+	// #bool true
+}
@@ -0,0 +1,22 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// Resize updates the pseudo-terminal window size for every PTY-backed
+	// stage of the pipeline.
+
+	// Example: resize
+	proc := execx.Command("go", "env", "GOOS").Start()
+	err := proc.Resize(40, 120)
+	_, _ = proc.Wait()
+	fmt.Println(err == execx.ErrNoPTY)
+	// #bool true
+}
@@ -0,0 +1,20 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// ResolveExitCode derives a POSIX-style exit code from an error
+	// returned by running a command.
+
+	// Example: resolve exit code
+	_, err := execx.Command("execx-no-such-binary").Run()
+	fmt.Println(execx.ResolveExitCode(err))
+	// #int 127
+}
@@ -0,0 +1,21 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// Resume continues every stage of the pipeline.
+
+	// Example: resume
+	proc := execx.Command("sleep", "2").Start()
+	_ = proc.Pause()
+	_ = proc.Resume()
+	fmt.Println(proc.Paused())
+	// #bool false
+}
@@ -12,7 +12,7 @@ func main() {
 	// Run executes the command and returns the result.
 
 	// Example: run
-	res := execx.Command("go", "env", "GOOS").Run()
+	res, _ := execx.Command("go", "env", "GOOS").Run()
 	fmt.Println(res.Stdout)
 	// darwin (or linux, windows, etc.)
 }
@@ -0,0 +1,22 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// Sandbox runs the child in new Linux namespaces, dropping
+	// capabilities and applying rlimits before it execs.
+
+	// Example: sandbox
+	cmd := execx.Command("go", "env", "GOOS").Sandbox(execx.SandboxSpec{
+		Namespaces: execx.SandboxNamespaces{PID: true, UTS: true, IPC: true},
+	})
+	fmt.Println(cmd != nil)
+	// #bool true
+}
@@ -0,0 +1,23 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/goforj/execx/agent"
+)
+
+func main() {
+	// Serve listens on network/address and serves remote execx requests
+	// until the listener is closed or an Accept error occurs.
+
+	// Example: serve
+	ln, _ := net.Listen("tcp", "127.0.0.1:0")
+	go agent.ServeListener(ln)
+	defer ln.Close()
+	fmt.Println(ln.Addr() != nil)
+	// #bool true
+}
@@ -0,0 +1,24 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/goforj/execx/agent"
+)
+
+func main() {
+	// ServeListener serves remote execx requests on an already-bound
+	// listener, so callers that need the assigned address can inspect
+	// ln.Addr() before requests start arriving.
+
+	// Example: serve listener
+	ln, _ := net.Listen("tcp", "127.0.0.1:0")
+	go agent.ServeListener(ln)
+	defer ln.Close()
+	fmt.Println(ln.Addr() != nil)
+	// #bool true
+}
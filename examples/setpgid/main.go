@@ -15,12 +15,4 @@ func main() {
 	cmd := execx.Command("go", "env", "GOOS").Setpgid(true)
 	fmt.Println(cmd != nil)
 	// #bool true
-	// Example: setpgid
-	cmd := execx.Command("go", "env", "GOOS").Setpgid(true)
-	fmt.Println(cmd != nil)
-	// #bool true
-	// Example: setpgid
-	cmd := execx.Command("go", "env", "GOOS").Setpgid(true)
-	fmt.Println(cmd != nil)
-	// #bool true
 }
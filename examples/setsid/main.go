@@ -15,12 +15,4 @@ func main() {
 	cmd := execx.Command("go", "env", "GOOS").Setsid(true)
 	fmt.Println(cmd != nil)
 	// #bool true
-	// Example: setsid
-	cmd := execx.Command("go", "env", "GOOS").Setsid(true)
-	fmt.Println(cmd != nil)
-	// #bool true
-	// Example: setsid
-	cmd := execx.Command("go", "env", "GOOS").Setsid(true)
-	fmt.Println(cmd != nil)
-	// #bool true
 }
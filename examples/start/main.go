@@ -13,7 +13,7 @@ func main() {
 
 	// Example: start
 	proc := execx.Command("go", "env", "GOOS").Start()
-	res := proc.Wait()
+	res, _ := proc.Wait()
 	fmt.Println(res.ExitCode == 0)
 	// #bool true
 }
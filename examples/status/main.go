@@ -0,0 +1,22 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// Status reports id's current SupervisorState.
+
+	// Example: status
+	sup := execx.NewSupervisor()
+	id := sup.Add(func() *execx.Cmd { return execx.Command("go", "env", "GOOS") },
+		execx.RestartNever, execx.Backoff{})
+	state, err := sup.Status(id)
+	fmt.Println(err == nil && state == execx.StateStopped)
+	// #bool true
+}
@@ -0,0 +1,24 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// StdioPaths returns the filesystem paths of the FIFOs backing this
+	// process's stdin, stdout, and stderr.
+
+	// Example: stdio paths
+	dir, _ := os.MkdirTemp("", "execx-fifo")
+	proc := execx.Command("go", "env", "GOOS").WithFifoIO(dir).Start()
+	_, stdout, _ := proc.StdioPaths()
+	_, _ = proc.Wait()
+	fmt.Println(stdout != "")
+	// #bool true
+}
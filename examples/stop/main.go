@@ -0,0 +1,27 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// Stop immediately terminates id's current attempt, if any, and
+	// prevents it from being restarted.
+
+	// Example: stop
+	sup := execx.NewSupervisor()
+	id := sup.Add(func() *execx.Cmd { return execx.Command("sleep", "2") },
+		execx.RestartAlways, execx.Backoff{})
+	sup.Start(context.Background())
+	time.Sleep(20 * time.Millisecond)
+	err := sup.Stop(id)
+	fmt.Println(err == nil)
+	// #bool true
+}
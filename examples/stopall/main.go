@@ -0,0 +1,27 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// StopAll stops every managed command and cancels the context Start
+	// was given, so nothing restarts afterward.
+
+	// Example: stop all
+	sup := execx.NewSupervisor()
+	sup.Add(func() *execx.Cmd { return execx.Command("sleep", "2") },
+		execx.RestartAlways, execx.Backoff{})
+	sup.Start(context.Background())
+	time.Sleep(20 * time.Millisecond)
+	err := sup.StopAll(100 * time.Millisecond)
+	fmt.Println(err == nil)
+	// #bool true
+}
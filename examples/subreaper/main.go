@@ -0,0 +1,24 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// EnableSubreaper marks this process as a Linux child subreaper and
+	// starts a goroutine that reaps adopted orphans.
+
+	// Example: enable subreaper
+	stop, err := execx.EnableSubreaper()
+	fmt.Println(err == nil || errors.Is(err, execx.ErrSubreaperUnsupported))
+	if stop != nil {
+		_ = stop()
+	}
+	// #bool true
+}
@@ -0,0 +1,19 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// Events returns the channel SupervisorEvents are delivered on.
+
+	// Example: supervisor events
+	sup := execx.NewSupervisor()
+	fmt.Println(sup.Events() != nil)
+	// #bool true
+}
@@ -0,0 +1,26 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// Start launches every command Add has registered so far, each in its
+	// own goroutine, and ties their restart loops to ctx.
+
+	// Example: supervisor start
+	sup := execx.NewSupervisor()
+	sup.Add(func() *execx.Cmd { return execx.Command("go", "env", "GOOS") },
+		execx.RestartNever, execx.Backoff{})
+	sup.Start(context.Background())
+	time.Sleep(50 * time.Millisecond)
+	fmt.Println(sup != nil)
+	// #bool true
+}
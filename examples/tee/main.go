@@ -0,0 +1,25 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// Tee duplicates this stage's stdout into dst in addition to whatever
+	// else consumes it.
+
+	// Example: tee
+	var sink strings.Builder
+	out, _ := execx.Command("printf", "go").
+		Tee(&sink).
+		Pipe("tr", "a-z", "A-Z").
+		OutputTrimmed()
+	fmt.Println(out == "GO" && sink.String() == "go")
+	// #bool true
+}
@@ -0,0 +1,20 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// TimedOut reports whether the result represents an expired context
+	// deadline.
+
+	// Example: timed out
+	res, _ := execx.Command("go", "env", "GOOS").Run()
+	fmt.Println(res.TimedOut())
+	// #bool false
+}
@@ -0,0 +1,25 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// WithCache enables result caching for the whole pipeline using the
+	// given backend.
+
+	// Example: with cache
+	cache := execx.NewMemoryCache(64)
+	run := func() (execx.Result, error) {
+		return execx.Command("go", "env", "GOOS").WithCache(cache).Run()
+	}
+	first, _ := run()
+	second, _ := run()
+	fmt.Println(first.Stdout == second.Stdout)
+	// #bool true
+}
@@ -16,7 +16,7 @@ func main() {
 	// Example: with context
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
-	res := execx.Command("go", "env", "GOOS").WithContext(ctx).Run()
+	res, _ := execx.Command("go", "env", "GOOS").WithContext(ctx).Run()
 	fmt.Println(res.Err == nil)
 	// #bool true
 }
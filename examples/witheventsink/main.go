@@ -0,0 +1,24 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// WithEventSink registers a pluggable EventSink that receives every
+	// lifecycle and IO event for this command's whole pipeline.
+
+	// Example: with event sink
+	var buf bytes.Buffer
+	execx.Command("go", "env", "GOOS").
+		WithEventSink(execx.NewJSONLinesEventSink(&buf)).
+		Run()
+	fmt.Println(buf.Len() > 0)
+	// #bool true
+}
@@ -0,0 +1,25 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// WithFifoIO switches this command's stdio from in-process pipes to
+	// named FIFOs created under dir, so a detached process can reopen
+	// them later.
+
+	// Example: with fifo io
+	dir, _ := os.MkdirTemp("", "execx-fifo")
+	proc := execx.Command("go", "env", "GOOS").WithFifoIO(dir).Start()
+	stdin, stdout, stderr := proc.StdioPaths()
+	_, _ = proc.Wait()
+	fmt.Println(stdin != "" && stdout != "" && stderr != "")
+	// #bool true
+}
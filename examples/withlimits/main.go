@@ -0,0 +1,22 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// WithLimits caps the resources a command may consume.
+
+	// Example: with limits
+	res, _ := execx.Command("go", "env", "GOOS").
+		WithLimits(execx.Limits{CPUTime: 2 * time.Second, OpenFiles: 64}).
+		Run()
+	fmt.Println(res.ExitCode == 0)
+	// #bool true
+}
@@ -0,0 +1,22 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// WithPTYSize sizes the pseudo-terminal inline with WithPTY, instead
+	// of a separate PTYSize call.
+
+	// Example: with pty size
+	res, _ := execx.Command("go", "env", "GOOS").
+		WithPTY(execx.WithPTYSize(24, 80)).
+		Run()
+	fmt.Println(res.ExitCode == 0)
+	// #bool true
+}
@@ -0,0 +1,24 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// WithSinkJSONLines parses each line as a JSON object and re-emits
+	// it with stream, stage, pid, and ts fields added.
+
+	// Example: with sink json lines
+	var out strings.Builder
+	execx.Command("go", "env", "GOOS").
+		AddStdoutSink("structured", &out, execx.WithSinkJSONLines()).
+		Run()
+	fmt.Println(strings.Contains(out.String(), `"stream":"stdout"`))
+	// #bool true
+}
@@ -0,0 +1,26 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// WithSinkMask rewrites every line written to the sink through fn
+	// before it reaches the underlying writer.
+
+	// Example: with sink mask
+	var out strings.Builder
+	execx.Command("go", "env", "GOOS").
+		AddStdoutSink("masked", &out, execx.WithSinkMask(func(line string) string {
+			return strings.ReplaceAll(line, "darwin", "***")
+		})).
+		Run()
+	fmt.Println(!strings.Contains(out.String(), "darwin"))
+	// #bool true
+}
@@ -0,0 +1,23 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// WithSinkPrefix prepends prefix to every line written to the sink.
+
+	// Example: with sink prefix
+	var out strings.Builder
+	execx.Command("go", "env", "GOOS").
+		AddStdoutSink("prefixed", &out, execx.WithSinkPrefix("> ")).
+		Run()
+	fmt.Println(strings.HasPrefix(out.String(), "> "))
+	// #bool true
+}
@@ -0,0 +1,25 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goforj/execx"
+)
+
+func main() {
+	// WithSinkRateLimit drops lines written to the sink more often than
+	// once per interval.
+
+	// Example: with sink rate limit
+	var out strings.Builder
+	execx.Command("go", "env", "GOOS").
+		AddStdoutSink("throttled", &out, execx.WithSinkRateLimit(time.Minute)).
+		Run()
+	fmt.Println(out.Len() > 0)
+	// #bool true
+}
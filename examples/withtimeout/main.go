@@ -13,7 +13,7 @@ func main() {
 	// WithTimeout binds the command to a timeout.
 
 	// Example: with timeout
-	res := execx.Command("go", "env", "GOOS").WithTimeout(2 * time.Second).Run()
+	res, _ := execx.Command("go", "env", "GOOS").WithTimeout(2 * time.Second).Run()
 	fmt.Println(res.Err == nil)
 	// #bool true
 }
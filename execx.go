@@ -24,6 +24,7 @@ const (
 	envInherit envMode = iota
 	envOnly
 	envAppend
+	envHermetic
 )
 
 const (
@@ -56,20 +57,46 @@ type Cmd struct {
 	name string
 	args []string
 
-	env     map[string]string
-	envMode envMode
-	ctx     context.Context
-	cancel  context.CancelFunc
-	dir     string
-
-	stdin io.Reader
-
-	onStdout func(string)
-	onStderr func(string)
-	stdoutW  io.Writer
-	stderrW  io.Writer
-
-	sysProcAttr *syscall.SysProcAttr
+	env      map[string]string
+	envMode  envMode
+	envAllow []string
+	envDeny  []string
+	ctx      context.Context
+	cancel   context.CancelFunc
+	dir      string
+
+	stdin   io.Reader
+	fifoDir string
+
+	usePTY     bool
+	ptyRows    uint16
+	ptyCols    uint16
+	ptyStdin   io.Reader
+	ptyRawMode bool
+
+	onStdout         func(string)
+	onStderr         func(string)
+	stdoutW          io.Writer
+	stderrW          io.Writer
+	teeWriters       []io.Writer
+	stdoutSinks      []*outputSink
+	stderrSinks      []*outputSink
+	events           *eventSink
+	eventsChan       *eventChannel
+	eventsBuf        int
+	eventsDropOldest bool
+	eventSinks       []EventSink
+	routes           []lineRoute
+	router           *Router
+	onMetrics        func(Rusage)
+
+	sysProcAttr  *syscall.SysProcAttr
+	limits       *Limits
+	sandbox      *SandboxSpec
+	onExecCmd    func(*exec.Cmd)
+	cache        Cache
+	cacheNonZero bool
+	dialer       Dialer
 
 	next     *Cmd
 	root     *Cmd
@@ -191,6 +218,47 @@ func (c *Cmd) EnvAppend(values map[string]string) *Cmd {
 	return c
 }
 
+// HermeticEnv starts the command from an empty environment; only
+// variables added with Env or forwarded with EnvAllow are present.
+// @group Environment
+//
+// Example: hermetic env
+//
+//	cmd := execx.Command("go", "env", "GOOS").HermeticEnv().EnvAllow("PATH")
+//	fmt.Println(strings.Join(cmd.EnvList(), ",") != "")
+//	// #bool true
+func (c *Cmd) HermeticEnv() *Cmd {
+	c.envMode = envHermetic
+	return c
+}
+
+// EnvAllow forwards the named variables from the parent environment when
+// HermeticEnv is in effect.
+// @group Environment
+//
+// Example: env allow
+//
+//	cmd := execx.Command("go", "env", "GOOS").HermeticEnv().EnvAllow("PATH", "HOME")
+//	fmt.Println(len(cmd.EnvList()) > 0)
+//	// #bool true
+func (c *Cmd) EnvAllow(keys ...string) *Cmd {
+	c.envAllow = append(c.envAllow, keys...)
+	return c
+}
+
+// EnvDeny removes the named variables from an inherited environment.
+// @group Environment
+//
+// Example: env deny
+//
+//	cmd := execx.Command("go", "env", "GOOS").EnvDeny("GOOS")
+//	fmt.Println(cmd != nil)
+//	// #bool true
+func (c *Cmd) EnvDeny(keys ...string) *Cmd {
+	c.envDeny = append(c.envDeny, keys...)
+	return c
+}
+
 // Dir sets the working directory.
 // @group WorkingDir
 //
@@ -420,8 +488,42 @@ func (c *Cmd) Pipe(name string, args ...string) *Cmd {
 	next := &Cmd{
 		name:     name,
 		args:     append([]string{}, args...),
-		envMode:  envInherit,
+		envMode:  root.envMode,
+		envAllow: append([]string{}, root.envAllow...),
+		envDeny:  append([]string{}, root.envDeny...),
+		pipeMode: root.pipeMode,
+		dialer:   root.dialer,
+		root:     root,
+	}
+	last := root
+	for last.next != nil {
+		last = last.next
+	}
+	last.next = next
+	return next
+}
+
+// PipeOn pipes this stage's stdout into a new stage that runs against a
+// different dialer than the rest of the pipeline (or against the local
+// host, if dialer is nil), letting a pipeline splice across machines.
+// @group Pipelining
+//
+// Example: pipe on
+//
+//	dialer := execx.Remote("tcp://127.0.0.1:0")
+//	cmd := execx.Command("printf", "go").PipeOn(dialer, "tr", "a-z", "A-Z")
+//	fmt.Println(strings.Join(cmd.Args(), " "))
+//	// #string tr a-z A-Z
+func (c *Cmd) PipeOn(dialer Dialer, name string, args ...string) *Cmd {
+	root := c.rootCmd()
+	next := &Cmd{
+		name:     name,
+		args:     append([]string{}, args...),
+		envMode:  root.envMode,
+		envAllow: append([]string{}, root.envAllow...),
+		envDeny:  append([]string{}, root.envDeny...),
 		pipeMode: root.pipeMode,
+		dialer:   dialer,
 		root:     root,
 	}
 	last := root
@@ -432,6 +534,24 @@ func (c *Cmd) Pipe(name string, args ...string) *Cmd {
 	return next
 }
 
+// Tee duplicates this stage's stdout into dst in addition to whatever
+// else consumes it (the next Pipe stage, OutputWriter, events, ...).
+// @group Pipelining
+//
+// Example: tee
+//
+//	var sink strings.Builder
+//	out, _ := execx.Command("printf", "go").
+//		Tee(&sink).
+//		Pipe("tr", "a-z", "A-Z").
+//		OutputTrimmed()
+//	fmt.Println(out == "GO" && sink.String() == "go")
+//	// #bool true
+func (c *Cmd) Tee(dst io.Writer) *Cmd {
+	c.teeWriters = append(c.teeWriters, dst)
+	return c
+}
+
 // PipeStrict sets strict pipeline semantics (stop on first failure).
 // @group Pipelining
 //
@@ -488,7 +608,7 @@ func (c *Cmd) Args() []string {
 //	fmt.Println(strings.Join(cmd.EnvList(), ","))
 //	// #string A=1
 func (c *Cmd) EnvList() []string {
-	return buildEnv(c.envMode, c.env)
+	return buildEnv(c.envMode, c.env, c.envAllow, c.envDeny)
 }
 
 // String returns a human-readable representation of the command.
@@ -538,10 +658,30 @@ func (c *Cmd) ShellEscaped() string {
 //	fmt.Println(res.ExitCode == 0)
 //	// #bool true
 func (c *Cmd) Run() (Result, error) {
+	root := c.rootCmd()
+	if c.hasRemoteStage() {
+		result, _ := c.runRemoteChain()
+		return result, result.Err
+	}
+	var key string
+	if root.cache != nil && c.cacheEligible() {
+		if k, ok := c.cacheKey(); ok {
+			key = k
+			if cached, ok := root.cache.Get(key); ok {
+				return cachedSynthesize(cached), nil
+			}
+		}
+	}
+
 	pipe := c.newPipeline(false)
 	pipe.start()
 	pipe.wait()
-	result, _ := pipe.primaryResult(c.rootCmd().pipeMode)
+	pipe.closeEvents()
+	result, _ := pipe.primaryResult(root.pipeMode)
+
+	if key != "" && result.Err == nil && (result.ExitCode == 0 || root.cacheNonZero) {
+		root.cache.Put(key, result)
+	}
 	return result, result.Err
 }
 
@@ -593,9 +733,14 @@ func (c *Cmd) OutputTrimmed() (string, error) {
 //	fmt.Println(out != "")
 //	// #bool true
 func (c *Cmd) CombinedOutput() (string, error) {
+	if c.hasRemoteStage() {
+		result, combined := c.runRemoteChain()
+		return combined, result.Err
+	}
 	pipe := c.newPipeline(true)
 	pipe.start()
 	pipe.wait()
+	pipe.closeEvents()
 	result, combined := pipe.primaryResult(c.rootCmd().pipeMode)
 	return combined, result.Err
 }
@@ -611,9 +756,14 @@ func (c *Cmd) CombinedOutput() (string, error) {
 //	fmt.Println(err == nil && len(results) == 2)
 //	// #bool true
 func (c *Cmd) PipelineResults() ([]Result, error) {
+	if c.hasRemoteStage() {
+		results := c.runRemoteChainAll()
+		return results, firstResultErr(results)
+	}
 	pipe := c.newPipeline(false)
 	pipe.start()
 	pipe.wait()
+	pipe.closeEvents()
 	results := pipe.results()
 	return results, firstResultErr(results)
 }
@@ -628,16 +778,24 @@ func (c *Cmd) PipelineResults() ([]Result, error) {
 //	fmt.Println(res.ExitCode == 0)
 //	// #bool true
 func (c *Cmd) Start() *Process {
+	if c.hasRemoteStage() {
+		return c.startRemoteChain()
+	}
+	root := c.rootCmd()
 	pipe := c.newPipeline(false)
 	pipe.start()
 
 	proc := &Process{
 		pipeline: pipe,
-		mode:     c.rootCmd().pipeMode,
+		mode:     root.pipeMode,
 		done:     make(chan struct{}),
 	}
+	if root.eventsChan != nil {
+		proc.events = root.eventsChan.ch
+	}
 	go func() {
 		pipe.wait()
+		pipe.closeEvents()
 		result, _ := pipe.primaryResult(proc.mode)
 		proc.finish(result)
 	}()
@@ -659,18 +817,45 @@ func (c *Cmd) rootCmd() *Cmd {
 }
 
 func (c *Cmd) execCmd() *exec.Cmd {
-	cmd := exec.CommandContext(c.ctxOrBackground(), c.name, c.args...)
+	name, args := c.name, c.args
+	if c.limits != nil && limitsSupported() == nil {
+		name, args = wrapForLimits(name, args, *c.limits)
+	}
+	cmd := exec.CommandContext(c.ctxOrBackground(), name, args...)
 	if c.dir != "" {
 		cmd.Dir = c.dir
 	}
-	cmd.Env = buildEnv(c.envMode, c.env)
+	cmd.Env = buildEnv(c.envMode, c.env, c.envAllow, c.envDeny)
 	if c.sysProcAttr != nil {
 		cmd.SysProcAttr = c.sysProcAttr
 	}
+	if c.onExecCmd != nil {
+		c.onExecCmd(cmd)
+	}
 	return cmd
 }
 
-func (c *Cmd) stdoutWriter(buf *bytes.Buffer, withCombined bool, combined *bytes.Buffer) io.Writer {
+// OnExecCmd registers a hook invoked with the underlying *exec.Cmd right
+// before each stage starts, once dir, env, and SysProcAttr have already
+// been applied. It's the escape hatch for os/exec configuration execx
+// doesn't wrap directly; Sandbox uses it internally to apply namespace
+// and rlimit controls.
+// @group OS Controls
+//
+// Example: on exec cmd
+//
+//	var sawPath string
+//	res, _ := execx.Command("go", "env", "GOOS").
+//		OnExecCmd(func(cmd *exec.Cmd) { sawPath = cmd.Path }).
+//		Run()
+//	fmt.Println(sawPath != "" && res.ExitCode == 0)
+//	// #bool true
+func (c *Cmd) OnExecCmd(fn func(*exec.Cmd)) *Cmd {
+	c.onExecCmd = fn
+	return c
+}
+
+func (c *Cmd) stdoutWriter(buf *bytes.Buffer, withCombined bool, combined *bytes.Buffer, stageIndex int, cmd *exec.Cmd) io.Writer {
 	writers := []io.Writer{}
 	if c.stdoutW != nil {
 		writers = append(writers, c.stdoutW)
@@ -682,13 +867,26 @@ func (c *Cmd) stdoutWriter(buf *bytes.Buffer, withCombined bool, combined *bytes
 	if c.onStdout != nil {
 		writers = append(writers, &lineWriter{onLine: c.onStdout})
 	}
+	if c.events != nil {
+		writers = append(writers, &eventWriter{sink: c.events, kind: EventStdout})
+	}
+	if len(c.routes) > 0 {
+		writers = append(writers, &routingWriter{routes: c.routes, kind: EventStdout})
+	}
+	if c.router != nil {
+		writers = append(writers, &routerWriter{router: c.router, stream: EventStdout})
+	}
+	for _, sink := range c.stdoutSinks {
+		writers = append(writers, &sinkWriter{sink: sink, kind: EventStdout, stageIndex: stageIndex, cmd: cmd})
+	}
+	writers = append(writers, c.teeWriters...)
 	if len(writers) == 1 {
 		return buf
 	}
 	return io.MultiWriter(writers...)
 }
 
-func (c *Cmd) stderrWriter(buf *bytes.Buffer, withCombined bool, combined *bytes.Buffer) io.Writer {
+func (c *Cmd) stderrWriter(buf *bytes.Buffer, withCombined bool, combined *bytes.Buffer, stageIndex int, cmd *exec.Cmd) io.Writer {
 	writers := []io.Writer{}
 	if c.stderrW != nil {
 		writers = append(writers, c.stderrW)
@@ -700,12 +898,68 @@ func (c *Cmd) stderrWriter(buf *bytes.Buffer, withCombined bool, combined *bytes
 	if c.onStderr != nil {
 		writers = append(writers, &lineWriter{onLine: c.onStderr})
 	}
+	if c.events != nil {
+		writers = append(writers, &eventWriter{sink: c.events, kind: EventStderr})
+	}
+	if len(c.routes) > 0 {
+		writers = append(writers, &routingWriter{routes: c.routes, kind: EventStderr})
+	}
+	if c.router != nil {
+		writers = append(writers, &routerWriter{router: c.router, stream: EventStderr})
+	}
+	for _, sink := range c.stderrSinks {
+		writers = append(writers, &sinkWriter{sink: sink, kind: EventStderr, stageIndex: stageIndex, cmd: cmd})
+	}
 	if len(writers) == 1 {
 		return buf
 	}
 	return io.MultiWriter(writers...)
 }
 
+// ptyWriter builds the writer a PTY-backed stage tees its master side
+// into. The master carries the child's combined stdout and stderr, so
+// this mirrors stdoutWriter rather than splitting the two. It also
+// returns a flush func that delivers any line buffered by onStdout's
+// lineWriter once the master read loop hits EOF, since a PTY's last
+// line isn't guaranteed to end in a newline the way a pipe's is.
+func (c *Cmd) ptyWriter(buf *bytes.Buffer, withCombined bool, combined *bytes.Buffer, stageIndex int, cmd *exec.Cmd) (io.Writer, func()) {
+	writers := []io.Writer{}
+	if c.stdoutW != nil {
+		writers = append(writers, c.stdoutW)
+	}
+	writers = append(writers, buf)
+	if withCombined {
+		writers = append(writers, combined)
+	}
+	var lw *lineWriter
+	if c.onStdout != nil {
+		lw = &lineWriter{onLine: c.onStdout}
+		writers = append(writers, lw)
+	}
+	if c.events != nil {
+		writers = append(writers, &eventWriter{sink: c.events, kind: EventStdout})
+	}
+	if len(c.routes) > 0 {
+		writers = append(writers, &routingWriter{routes: c.routes, kind: EventStdout})
+	}
+	if c.router != nil {
+		writers = append(writers, &routerWriter{router: c.router, stream: EventStdout})
+	}
+	for _, sink := range c.stdoutSinks {
+		writers = append(writers, &sinkWriter{sink: sink, kind: EventStdout, stageIndex: stageIndex, cmd: cmd})
+	}
+	writers = append(writers, c.teeWriters...)
+	flush := func() {
+		if lw != nil {
+			lw.flush()
+		}
+	}
+	if len(writers) == 1 {
+		return buf, flush
+	}
+	return io.MultiWriter(writers...), flush
+}
+
 type lineWriter struct {
 	onLine func(string)
 	buf    bytes.Buffer
@@ -728,11 +982,36 @@ func (l *lineWriter) Write(p []byte) (int, error) {
 	return len(p), nil
 }
 
-func buildEnv(mode envMode, env map[string]string) []string {
+// flush delivers a final unterminated line once the writer's source is
+// known to be exhausted (the PTY master hit EOF). A normal pipe's last
+// line is always newline-terminated by the shell, but a PTY may not add
+// one, so without this the last line would never reach onLine.
+func (l *lineWriter) flush() {
+	if l.onLine == nil || l.buf.Len() == 0 {
+		return
+	}
+	line := strings.TrimSuffix(l.buf.String(), "\r")
+	l.buf.Reset()
+	l.onLine(line)
+}
+
+func buildEnv(mode envMode, env map[string]string, allow, deny []string) []string {
 	merged := map[string]string{}
-	if mode != envOnly {
+	switch mode {
+	case envHermetic:
+		for _, key := range allow {
+			if val, ok := os.LookupEnv(key); ok {
+				merged[key] = val
+			}
+		}
+	case envOnly:
+		// start empty; env below supplies every variable
+	default:
 		for _, entry := range os.Environ() {
 			key, val, _ := strings.Cut(entry, "=")
+			if containsKey(deny, key) {
+				continue
+			}
 			merged[key] = val
 		}
 	}
@@ -751,6 +1030,15 @@ func buildEnv(mode envMode, env map[string]string) []string {
 	return list
 }
 
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
 func firstResultErr(results []Result) error {
 	for _, res := range results {
 		if res.Err != nil {
@@ -774,13 +1062,16 @@ func shellEscape(arg string) string {
 // Process represents an asynchronously running command.
 type Process struct {
 	pipeline *pipeline
+	remote   *remoteSession
 	mode     pipeMode
 	done     chan struct{}
 	result   Result
+	events   <-chan Event
 
 	resultOnce sync.Once
 	mu         sync.Mutex
 	killTimer  *time.Timer
+	paused     bool
 }
 
 // Wait waits for the command to complete and returns the result and any error.
@@ -797,6 +1088,30 @@ func (p *Process) Wait() (Result, error) {
 	return p.result, p.result.Err
 }
 
+// Pid returns the OS process ID of the pipeline's last stage (the
+// "primary" stage everywhere else in this package treats a pipeline's
+// outcome as coming from), or 0 if the process hasn't started yet or
+// this Process wraps a remote session instead of a local pipeline.
+// @group Process
+//
+// Example: pid
+//
+//	proc := execx.Command("go", "env", "GOOS").Start()
+//	pid := proc.Pid()
+//	_, _ = proc.Wait()
+//	fmt.Println(pid > 0)
+//	// #bool true
+func (p *Process) Pid() int {
+	if p == nil || p.pipeline == nil || len(p.pipeline.stages) == 0 {
+		return 0
+	}
+	last := p.pipeline.stages[len(p.pipeline.stages)-1]
+	if last == nil || last.cmd == nil || last.cmd.Process == nil {
+		return 0
+	}
+	return last.cmd.Process.Pid
+}
+
 // KillAfter terminates the process after the given duration.
 // @group Process
 //
@@ -829,7 +1144,10 @@ func (p *Process) KillAfter(d time.Duration) {
 //	fmt.Println(err != nil || res.ExitCode != 0)
 //	// #bool true
 func (p *Process) Send(sig os.Signal) error {
-	return p.signalAll(func(proc *os.Process) error {
+	if p.remote != nil {
+		return p.remote.signal(sig)
+	}
+	return p.signalAll(sig, func(proc *os.Process) error {
 		return proc.Signal(sig)
 	})
 }
@@ -859,7 +1177,10 @@ func (p *Process) Interrupt() error {
 //	fmt.Println(err != nil || res.ExitCode != 0)
 //	// #bool true
 func (p *Process) Terminate() error {
-	return p.signalAll(func(proc *os.Process) error {
+	if p.remote != nil {
+		return p.remote.terminate()
+	}
+	return p.signalAll(os.Kill, func(proc *os.Process) error {
 		return proc.Kill()
 	})
 }
@@ -898,7 +1219,7 @@ func (p *Process) finish(result Result) {
 	})
 }
 
-func (p *Process) signalAll(send func(*os.Process) error) error {
+func (p *Process) signalAll(sig os.Signal, send func(*os.Process) error) error {
 	if p == nil || p.pipeline == nil {
 		return errors.New("process not started")
 	}
@@ -909,7 +1230,13 @@ func (p *Process) signalAll(send func(*os.Process) error) error {
 			continue
 		}
 		count++
-		if err := send(stage.cmd.Process); err != nil && firstErr == nil {
+		err := send(stage.cmd.Process)
+		if err == nil {
+			if sink := stage.def.events; sink != nil {
+				sink.emit(Event{Kind: EventSignal, Message: sig.String()})
+			}
+		}
+		if err != nil && firstErr == nil {
 			firstErr = err
 		}
 	}
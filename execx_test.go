@@ -48,6 +48,10 @@ func TestHelperProcess(t *testing.T) {
 	case "lines":
 		_, _ = io.WriteString(os.Stdout, "a\nb\n")
 		_, _ = io.WriteString(os.Stderr, "c\n")
+	case "block":
+		_, _ = io.WriteString(os.Stdout, "before\n---\ninside1\ninside2\n---\nafter\n")
+	case "json":
+		_, _ = io.WriteString(os.Stdout, `{"msg":"hi"}`+"\n")
 	case "env":
 		_, _ = io.WriteString(os.Stdout, os.Getenv(cmdArgs[0]))
 	case "sleep":
@@ -56,12 +60,30 @@ func TestHelperProcess(t *testing.T) {
 	case "pwd":
 		wd, _ := os.Getwd()
 		_, _ = io.WriteString(os.Stdout, wd)
+	case "isatty":
+		fi, err := os.Stdin.Stat()
+		isatty := err == nil && fi.Mode()&os.ModeCharDevice != 0
+		_, _ = io.WriteString(os.Stdout, strconv.FormatBool(isatty))
 	case "signal":
 		if runtime.GOOS == "windows" {
 			os.Exit(3)
 		}
 		_ = syscall.Kill(os.Getpid(), syscall.SIGTERM)
 		time.Sleep(50 * time.Millisecond)
+	case "killself":
+		if runtime.GOOS == "windows" {
+			os.Exit(3)
+		}
+		_ = syscall.Kill(os.Getpid(), syscall.SIGKILL)
+		time.Sleep(50 * time.Millisecond)
+	case "burst":
+		for i := 0; i < 5; i++ {
+			_, _ = io.WriteString(os.Stdout, "x")
+			time.Sleep(10 * time.Millisecond)
+		}
+	case "firstbyte":
+		buf := make([]byte, 1)
+		_, _ = io.ReadFull(os.Stdin, buf)
 	default:
 		os.Exit(1)
 	}
@@ -172,6 +194,54 @@ func TestEnvList(t *testing.T) {
 	}
 }
 
+func TestHermeticEnvAllow(t *testing.T) {
+	t.Setenv("EXECX_ENV_VALUE", "base")
+
+	cmd := helperCommand("env", "EXECX_ENV_VALUE").HermeticEnv().EnvAllow("EXECX_ENV_VALUE", "EXECX_TEST_HELPER")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if out != "base" {
+		t.Fatalf("expected allowlisted env to be forwarded, got %q", out)
+	}
+
+	cmd = helperCommand("env", "EXECX_ENV_VALUE").HermeticEnv()
+	cmd.Env("EXECX_TEST_HELPER=1")
+	out, err = cmd.Output()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if out != "" {
+		t.Fatalf("expected hermetic env to drop unlisted vars, got %q", out)
+	}
+}
+
+func TestEnvDeny(t *testing.T) {
+	t.Setenv("EXECX_ENV_VALUE", "base")
+	cmd := helperCommand("env", "EXECX_ENV_VALUE").EnvDeny("EXECX_ENV_VALUE")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if out != "" {
+		t.Fatalf("expected denied env var to be stripped, got %q", out)
+	}
+}
+
+func TestEnvModePropagatesAcrossPipe(t *testing.T) {
+	t.Setenv("EXECX_ENV_VALUE", "base")
+	cmd := helperCommand("env", "EXECX_ENV_VALUE").EnvDeny("EXECX_ENV_VALUE")
+	stage := helperPipe(cmd, "env", "EXECX_ENV_VALUE")
+	out, err := stage.Output()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if out != "" {
+		t.Fatalf("expected pipeline stage to inherit deny list, got %q", out)
+	}
+}
+
 func TestStdinHelpers(t *testing.T) {
 	cases := []struct {
 		name string
@@ -253,7 +323,7 @@ func TestOutputVariants(t *testing.T) {
 }
 
 func TestExitHelpers(t *testing.T) {
-	res := helperCommand("exit", "2").Run()
+	res, _ := helperCommand("exit", "2").Run()
 	if res.OK() {
 		t.Fatalf("expected not OK")
 	}
@@ -269,14 +339,14 @@ func TestIsSignal(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("signals not supported on windows")
 	}
-	res := helperCommand("signal").Run()
+	res, _ := helperCommand("signal").Run()
 	if !res.IsSignal(syscall.SIGTERM) {
 		t.Fatalf("expected SIGTERM, got %v", res.signal)
 	}
 }
 
 func TestWithTimeout(t *testing.T) {
-	res := helperCommand("sleep", "200").WithTimeout(50 * time.Millisecond).Run()
+	res, _ := helperCommand("sleep", "200").WithTimeout(50 * time.Millisecond).Run()
 	if res.Err == nil {
 		t.Fatalf("expected timeout error")
 	}
@@ -284,19 +354,19 @@ func TestWithTimeout(t *testing.T) {
 		t.Fatalf("expected context error, got %v", res.Err)
 	}
 
-	res = helperCommand("sleep", "50").WithTimeout(10 * time.Millisecond).WithTimeout(5 * time.Millisecond).Run()
+	res, _ = helperCommand("sleep", "50").WithTimeout(10 * time.Millisecond).WithTimeout(5 * time.Millisecond).Run()
 	if res.Err == nil {
 		t.Fatalf("expected timeout error on repeated call")
 	}
 }
 
 func TestWithDeadline(t *testing.T) {
-	res := helperCommand("sleep", "100").WithDeadline(time.Now().Add(10 * time.Millisecond)).Run()
+	res, _ := helperCommand("sleep", "100").WithDeadline(time.Now().Add(10 * time.Millisecond)).Run()
 	if res.Err == nil {
 		t.Fatalf("expected deadline error")
 	}
 
-	res = helperCommand("echo", "ok").WithDeadline(time.Now().Add(200 * time.Millisecond)).WithDeadline(time.Now().Add(300 * time.Millisecond)).Run()
+	res, _ = helperCommand("echo", "ok").WithDeadline(time.Now().Add(200 * time.Millisecond)).WithDeadline(time.Now().Add(300 * time.Millisecond)).Run()
 	if res.Err != nil {
 		t.Fatalf("expected no error, got %v", res.Err)
 	}
@@ -305,12 +375,12 @@ func TestWithDeadline(t *testing.T) {
 func TestWithContext(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
-	res := helperCommand("sleep", "50").WithContext(ctx).Run()
+	res, _ := helperCommand("sleep", "50").WithContext(ctx).Run()
 	if res.Err == nil {
 		t.Fatalf("expected canceled error")
 	}
 
-	res = helperCommand("echo", "ok").WithTimeout(500 * time.Millisecond).WithContext(context.Background()).Run()
+	res, _ = helperCommand("echo", "ok").WithTimeout(500 * time.Millisecond).WithContext(context.Background()).Run()
 	if res.Err != nil {
 		t.Fatalf("expected no error, got %v", res.Err)
 	}
@@ -336,12 +406,12 @@ func TestDir(t *testing.T) {
 }
 
 func TestPipeModes(t *testing.T) {
-	strictRes := helperPipe(helperCommand("exit", "2"), "echo", "ok").Run()
+	strictRes, _ := helperPipe(helperCommand("exit", "2"), "echo", "ok").Run()
 	if strictRes.ExitCode != 2 {
 		t.Fatalf("expected strict pipeline to return first failure, got %d", strictRes.ExitCode)
 	}
 
-	bestEffortRes := helperPipe(helperCommand("exit", "2").PipeBestEffort(), "echo", "ok").Run()
+	bestEffortRes, _ := helperPipe(helperCommand("exit", "2").PipeBestEffort(), "echo", "ok").Run()
 	if bestEffortRes.ExitCode != 0 {
 		t.Fatalf("expected best effort to return last stage, got %d", bestEffortRes.ExitCode)
 	}
@@ -354,7 +424,7 @@ func TestPipeChain(t *testing.T) {
 	root := helperCommand("echo", "a")
 	stage := helperPipe(root, "echo", "b")
 	final := helperPipe(stage, "echo", "c")
-	res := final.Run()
+	res, _ := final.Run()
 	if res.Stdout != "c" {
 		t.Fatalf("expected last stage output, got %q", res.Stdout)
 	}
@@ -363,12 +433,12 @@ func TestPipeChain(t *testing.T) {
 func TestPipeStartError(t *testing.T) {
 	bad := Command("execx-does-not-exist")
 	stage := helperPipe(bad, "echo", "ok")
-	res := stage.Run()
+	res, _ := stage.Run()
 	if res.Err == nil {
 		t.Fatalf("expected start error")
 	}
-	if res.ExitCode != -1 {
-		t.Fatalf("expected exit code -1, got %d", res.ExitCode)
+	if res.ExitCode != 127 {
+		t.Fatalf("expected exit code 127, got %d", res.ExitCode)
 	}
 }
 
@@ -394,7 +464,7 @@ func TestStringAndShellEscaped(t *testing.T) {
 func TestLineCallbacks(t *testing.T) {
 	var stdoutLines []string
 	var stderrLines []string
-	res := helperCommand("lines").OnStdout(func(line string) {
+	res, _ := helperCommand("lines").OnStdout(func(line string) {
 		stdoutLines = append(stdoutLines, line)
 	}).OnStderr(func(line string) {
 		stderrLines = append(stderrLines, line)
@@ -412,19 +482,19 @@ func TestLineCallbacks(t *testing.T) {
 
 func TestStartAndWait(t *testing.T) {
 	proc := helperCommand("sleep", "50").Start()
-	res := proc.Wait()
+	res, _ := proc.Wait()
 	if res.ExitCode != 0 || res.Err != nil {
 		t.Fatalf("expected clean exit, got code=%d err=%v", res.ExitCode, res.Err)
 	}
 }
 
 func TestStartError(t *testing.T) {
-	res := Command("execx-does-not-exist").Run()
+	res, _ := Command("execx-does-not-exist").Run()
 	if res.Err == nil {
 		t.Fatalf("expected start error")
 	}
-	if res.ExitCode != -1 {
-		t.Fatalf("expected exit code -1 for start error, got %d", res.ExitCode)
+	if res.ExitCode != 127 {
+		t.Fatalf("expected exit code 127 for start error, got %d", res.ExitCode)
 	}
 }
 
@@ -465,7 +535,7 @@ func TestStageResultContextError(t *testing.T) {
 }
 
 func TestPipeStrictExplicit(t *testing.T) {
-	res := helperPipe(helperCommand("exit", "2").PipeStrict(), "echo", "ok").Run()
+	res, _ := helperPipe(helperCommand("exit", "2").PipeStrict(), "echo", "ok").Run()
 	if res.ExitCode != 2 {
 		t.Fatalf("expected strict pipeline to return first failure, got %d", res.ExitCode)
 	}
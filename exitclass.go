@@ -0,0 +1,147 @@
+package execx
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"syscall"
+)
+
+// ExitClass categorizes how a Result's exit code came about, so callers
+// can branch on "why" instead of re-deriving it from ExitCode/Err/signal
+// themselves.
+type ExitClass int
+
+const (
+	// ClassOK is a clean exit with code 0 and no error.
+	ClassOK ExitClass = iota
+	// ClassChildExit is an ordinary nonzero exit from the child.
+	ClassChildExit
+	// ClassSignaled means the child was terminated by a signal.
+	ClassSignaled
+	// ClassTimeout means the command's context deadline expired.
+	ClassTimeout
+	// ClassCancelled means the command's context was cancelled.
+	ClassCancelled
+	// ClassStartupFailure means the child never ran, for a reason other
+	// than ClassNotFound/ClassPermission (e.g. a working directory that
+	// doesn't exist).
+	ClassStartupFailure
+	// ClassNotFound means the executable could not be found, the
+	// ENOENT/"file not found in $PATH" case.
+	ClassNotFound
+	// ClassPermission means the executable could not be run because of
+	// its file permissions, the EACCES case.
+	ClassPermission
+)
+
+// ErrNotFound is the sentinel ClassNotFound failures satisfy via
+// ErrExec.Is, so callers can write errors.Is(err, execx.ErrNotFound).
+var ErrNotFound = errors.New("execx: command not found")
+
+// ErrPermission is the sentinel ClassPermission failures satisfy via
+// ErrExec.Is, so callers can write errors.Is(err, execx.ErrPermission).
+var ErrPermission = errors.New("execx: permission denied")
+
+// Classify reports how this result's exit code should be interpreted.
+// @group Results
+//
+// Example: classify
+//
+//	res, _ := execx.Command("go", "env", "GOOS").Run()
+//	fmt.Println(res.Classify() == execx.ClassOK)
+//	// #bool true
+func (r Result) Classify() ExitClass {
+	if ee, ok := r.Err.(ErrExec); ok {
+		return ee.Class
+	}
+	switch {
+	case errors.Is(r.Err, context.Canceled):
+		return ClassCancelled
+	case errors.Is(r.Err, context.DeadlineExceeded):
+		return ClassTimeout
+	case r.signal != nil:
+		return ClassSignaled
+	case r.Err == nil && r.ExitCode == 0:
+		return ClassOK
+	default:
+		return ClassChildExit
+	}
+}
+
+// classifyStartErr maps a failure to start a command (as returned by
+// exec.Cmd.Start) to an ExitClass, the same ENOENT/EACCES distinction a
+// shell makes when it reports 127 or 126 for "command not found" and
+// "permission denied".
+func classifyStartErr(err error) ExitClass {
+	switch {
+	case errors.Is(err, exec.ErrNotFound), errors.Is(err, syscall.ENOENT):
+		return ClassNotFound
+	case errors.Is(err, syscall.EACCES):
+		return ClassPermission
+	default:
+		return ClassStartupFailure
+	}
+}
+
+// startupExitCode mirrors the POSIX shell convention for a command that
+// never ran: 127 for "not found", 126 for "found but not executable".
+func startupExitCode(class ExitClass) int {
+	switch class {
+	case ClassPermission:
+		return 126
+	default:
+		return 127
+	}
+}
+
+// TerminationReason categorizes why a process is no longer running. It's
+// a coarser, termination-focused view than ExitClass: it collapses the
+// NotFound/Permission/StartupFailure split into a single TerminationStartFailed,
+// but splits ClassSignaled further into TerminationKilled (SIGKILL
+// specifically) and TerminationSignaled (any other signal).
+type TerminationReason int
+
+const (
+	// TerminationExited means the process ran and exited on its own,
+	// cleanly or not, without being signaled or cancelled.
+	TerminationExited TerminationReason = iota
+	// TerminationSignaled means the process was terminated by a signal
+	// other than SIGKILL.
+	TerminationSignaled
+	// TerminationKilled means the process was terminated by SIGKILL.
+	TerminationKilled
+	// TerminationTimedOut means the command's context deadline expired.
+	TerminationTimedOut
+	// TerminationCanceled means the command's context was cancelled.
+	TerminationCanceled
+	// TerminationStartFailed means the process never started at all.
+	TerminationStartFailed
+)
+
+// TerminationReason reports why this result's process is no longer
+// running.
+// @group Results
+//
+// Example: termination reason
+//
+//	res, _ := execx.Command("go", "env", "GOOS").Run()
+//	fmt.Println(res.TerminationReason() == execx.TerminationExited)
+//	// #bool true
+func (r Result) TerminationReason() TerminationReason {
+	switch r.Classify() {
+	case ClassCancelled:
+		return TerminationCanceled
+	case ClassTimeout:
+		return TerminationTimedOut
+	case ClassSignaled:
+		if r.IsKilled() {
+			return TerminationKilled
+		}
+		return TerminationSignaled
+	case ClassStartupFailure, ClassNotFound, ClassPermission:
+		return TerminationStartFailed
+	default:
+		return TerminationExited
+	}
+}
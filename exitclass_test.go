@@ -0,0 +1,181 @@
+package execx
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestResolveExitCodeNil(t *testing.T) {
+	if code := ResolveExitCode(nil); code != 0 {
+		t.Fatalf("expected 0, got %d", code)
+	}
+}
+
+func TestResolveExitCodeNotFound(t *testing.T) {
+	_, err := Command("execx-no-such-binary").Run()
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if code := ResolveExitCode(err); code != 127 {
+		t.Fatalf("expected 127, got %d", code)
+	}
+}
+
+func TestResultClassifyOK(t *testing.T) {
+	res, err := helperCommand("echo", "hi").Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res.Classify() != ClassOK {
+		t.Fatalf("expected ClassOK, got %v", res.Classify())
+	}
+}
+
+func TestResultClassifyStartupFailure(t *testing.T) {
+	res, err := Command("execx-no-such-binary").Run()
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if res.Classify() != ClassNotFound {
+		t.Fatalf("expected ClassNotFound, got %v", res.Classify())
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected errors.Is(err, ErrNotFound)")
+	}
+	if res.ExitCode != 127 {
+		t.Fatalf("expected exit code 127, got %d", res.ExitCode)
+	}
+}
+
+func TestErrExecIsMatchesClass(t *testing.T) {
+	notFound := ErrExec{Err: errors.New("boom"), Class: ClassNotFound}
+	if !errors.Is(notFound, ErrNotFound) {
+		t.Fatalf("expected notFound to match ErrNotFound")
+	}
+	if errors.Is(notFound, ErrPermission) {
+		t.Fatalf("expected notFound not to match ErrPermission")
+	}
+
+	denied := ErrExec{Err: errors.New("boom"), Class: ClassPermission}
+	if !errors.Is(denied, ErrPermission) {
+		t.Fatalf("expected denied to match ErrPermission")
+	}
+}
+
+func TestResultIsSignaled(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("signals not supported on windows")
+	}
+	res, _ := helperCommand("signal").Run()
+	if !res.IsSignaled() {
+		t.Fatalf("expected IsSignaled, got %v", res.signal)
+	}
+	res, err := helperCommand("echo", "hi").Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res.IsSignaled() {
+		t.Fatalf("expected no signal for a clean exit")
+	}
+}
+
+func TestResultIsKilled(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("signals not supported on windows")
+	}
+	res, _ := helperCommand("killself").Run()
+	if !res.IsKilled() {
+		t.Fatalf("expected IsKilled, got %v", res.signal)
+	}
+	res, _ = helperCommand("signal").Run()
+	if res.IsKilled() {
+		t.Fatalf("expected SIGTERM not to count as killed, got %v", res.signal)
+	}
+	if !res.IsSignal(syscall.SIGTERM) {
+		t.Fatalf("expected SIGTERM")
+	}
+}
+
+func TestResultIsCanceled(t *testing.T) {
+	res, _ := helperCommand("sleep", "200").WithTimeout(50 * time.Millisecond).Run()
+	if res.IsCanceled() {
+		t.Fatalf("expected a deadline expiry to classify as ClassTimeout, not ClassCancelled")
+	}
+	if res.Classify() != ClassTimeout {
+		t.Fatalf("expected ClassTimeout, got %v", res.Classify())
+	}
+
+	res, err := helperCommand("echo", "hi").Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res.IsCanceled() {
+		t.Fatalf("expected no cancellation for a clean exit")
+	}
+}
+
+func TestResultIsStartError(t *testing.T) {
+	res, err := Command("execx-no-such-binary").Run()
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if !res.IsStartError() {
+		t.Fatalf("expected IsStartError, got Classify()=%v", res.Classify())
+	}
+
+	res, err = helperCommand("echo", "hi").Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res.IsStartError() {
+		t.Fatalf("expected no start error for a clean exit")
+	}
+}
+
+func TestResultTerminationReason(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("signals not supported on windows")
+	}
+
+	res, err := helperCommand("echo", "hi").Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := res.TerminationReason(); got != TerminationExited {
+		t.Fatalf("expected TerminationExited, got %v", got)
+	}
+
+	res, _ = helperCommand("signal").Run()
+	if got := res.TerminationReason(); got != TerminationSignaled {
+		t.Fatalf("expected TerminationSignaled, got %v", got)
+	}
+
+	res, _ = helperCommand("killself").Run()
+	if got := res.TerminationReason(); got != TerminationKilled {
+		t.Fatalf("expected TerminationKilled, got %v", got)
+	}
+
+	res, _ = helperCommand("sleep", "200").WithTimeout(50 * time.Millisecond).Run()
+	if got := res.TerminationReason(); got != TerminationTimedOut {
+		t.Fatalf("expected TerminationTimedOut, got %v", got)
+	}
+
+	res, err = Command("execx-no-such-binary").Run()
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if got := res.TerminationReason(); got != TerminationStartFailed {
+		t.Fatalf("expected TerminationStartFailed, got %v", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	res, _ = helperCommand("sleep", "200").WithContext(ctx).Run()
+	if got := res.TerminationReason(); got != TerminationCanceled {
+		t.Fatalf("expected TerminationCanceled, got %v", got)
+	}
+}
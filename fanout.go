@@ -0,0 +1,163 @@
+package execx
+
+import (
+	"io"
+	"sync"
+)
+
+// FanOut duplicates a producer command's stdout into N consumer commands
+// that run concurrently, with FanIn collecting their results. It composes
+// with Tee: internally, fanning out is implemented as a tee into one pipe
+// per branch.
+type FanOut struct {
+	source   *Cmd
+	branches []*Cmd
+}
+
+// branchTee wraps one branch's io.PipeWriter so the producer can keep
+// writing after the branch has finished reading. Once done is called,
+// Write silently discards instead of blocking on a pipe nobody drains
+// anymore, which is what lets a branch like `head -c N` finish before
+// the producer does without deadlocking the rest of the fan-out.
+type branchTee struct {
+	mu     sync.Mutex
+	w      *io.PipeWriter
+	closed bool
+}
+
+func (b *branchTee) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return len(p), nil
+	}
+	return b.w.Write(p)
+}
+
+func (b *branchTee) done() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	_ = b.w.Close()
+}
+
+// FanOutResult collects the producer's Result alongside one Result per
+// branch, in the order the branches were given to FanOut.
+type FanOutResult struct {
+	Producer Result
+	Branches []Result
+}
+
+// OK reports whether the producer and every branch exited cleanly.
+// @group Pipelining
+//
+// Example: fan out result ok
+//
+//	res := execx.FanOutResult{Producer: execx.Result{}, Branches: []execx.Result{{}}}
+//	fmt.Println(res.OK())
+//	// #bool true
+func (r FanOutResult) OK() bool {
+	if !r.Producer.OK() {
+		return false
+	}
+	for _, branch := range r.Branches {
+		if !branch.OK() {
+			return false
+		}
+	}
+	return true
+}
+
+// FanOut forks this command's stdout into the given branch commands,
+// which run concurrently once the producer starts.
+// @group Pipelining
+//
+// Example: fan out
+//
+//	var upper, title strings.Builder
+//	out := execx.Command("printf", "go").
+//		FanOut(
+//			execx.Command("tr", "a-z", "A-Z").StdoutWriter(&upper),
+//			execx.Command("cat").StdoutWriter(&title),
+//		)
+//	res, _ := out.Run()
+//	fmt.Println(res.OK() && upper.String() == "GO")
+//	// #bool true
+func (c *Cmd) FanOut(branches ...*Cmd) *FanOut {
+	return &FanOut{source: c, branches: branches}
+}
+
+// Run starts the producer and every branch concurrently, feeding each
+// branch its own copy of the producer's stdout (FanIn). In strict pipeline
+// mode a branch that exits non-zero terminates the producer; the other
+// branches are not signaled and run until their input closes, and a
+// producer that fails signals no branch. In best-effort mode every branch
+// runs to completion. In both modes every branch's exit code is surfaced
+// on FanOutResult.Branches.
+// @group Pipelining
+//
+// Example: fan in
+//
+//	var upper strings.Builder
+//	out := execx.Command("printf", "go").
+//		FanOut(execx.Command("tr", "a-z", "A-Z").StdoutWriter(&upper))
+//	res, _ := out.Run()
+//	fmt.Println(res.OK())
+//	// #bool true
+func (f *FanOut) Run() (FanOutResult, error) {
+	root := f.source.rootCmd()
+
+	readers := make([]*io.PipeReader, len(f.branches))
+	tees := make([]*branchTee, len(f.branches))
+	writers := make([]io.Writer, len(f.branches))
+	for i := range f.branches {
+		r, w := io.Pipe()
+		readers[i] = r
+		tees[i] = &branchTee{w: w}
+		writers[i] = tees[i]
+	}
+	f.source.teeWriters = append(f.source.teeWriters, writers...)
+
+	proc := f.source.Start()
+
+	branchResults := make([]Result, len(f.branches))
+	var wg sync.WaitGroup
+	for i, branch := range f.branches {
+		wg.Add(1)
+		go func(i int, branch *Cmd) {
+			defer wg.Done()
+			// Stop forwarding producer output to this branch the moment
+			// it's done, even if it exited before the producer finished
+			// (e.g. `head -c N`); otherwise the next producer write into
+			// the MultiWriter blocks forever on a reader nobody drains.
+			defer tees[i].done()
+			branchResults[i], _ = branch.StdinReader(readers[i]).Run()
+			if root.pipeMode == pipeStrict && !branchResults[i].OK() {
+				_ = proc.Terminate()
+			}
+		}(i, branch)
+	}
+
+	producer, producerErr := proc.Wait()
+	for _, t := range tees {
+		t.done()
+	}
+	wg.Wait()
+
+	result := FanOutResult{Producer: producer, Branches: branchResults}
+	if producerErr != nil {
+		return result, producerErr
+	}
+	if root.pipeMode == pipeStrict {
+		for _, branch := range branchResults {
+			if branch.Err != nil {
+				return result, branch.Err
+			}
+		}
+	}
+	return result, nil
+}
+
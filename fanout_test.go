@@ -0,0 +1,69 @@
+package execx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTeeDuplicatesStdout(t *testing.T) {
+	var sink teeBuffer
+	out, err := helperCommand("echo", "go").Tee(&sink).Output()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if out != "go" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+	if sink.String() != "go" {
+		t.Fatalf("expected tee sink to receive stdout, got %q", sink.String())
+	}
+}
+
+func TestFanOutRunsBranchesConcurrently(t *testing.T) {
+	var branchOut teeBuffer
+	fan := helperCommand("echo", "go").FanOut(
+		helperCommand("cat").StdoutWriter(&branchOut),
+	)
+	res, err := fan.Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !res.OK() {
+		t.Fatalf("expected producer and branches to succeed: %+v", res)
+	}
+}
+
+// TestFanOutDrainsFinishedBranchWithoutDeadlock guards against a producer
+// write blocking forever after one branch exits early (e.g. a `head -c N`
+// style consumer) while the producer is still writing to the shared
+// io.MultiWriter tee.
+func TestFanOutDrainsFinishedBranchWithoutDeadlock(t *testing.T) {
+	fan := helperCommand("burst").FanOut(
+		helperCommand("firstbyte"),
+	)
+
+	done := make(chan struct{})
+	go func() {
+		_, _ = fan.Run()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("FanOut.Run deadlocked after a branch exited early")
+	}
+}
+
+type teeBuffer struct {
+	data []byte
+}
+
+func (b *teeBuffer) Write(p []byte) (int, error) {
+	b.data = append(b.data, p...)
+	return len(p), nil
+}
+
+func (b *teeBuffer) String() string {
+	return string(b.data)
+}
@@ -0,0 +1,70 @@
+package execx
+
+import "errors"
+
+// ErrFifoUnsupported is returned when WithFifoIO is used on a platform
+// with no FIFO equivalent, surfaced through Result.Err the same way
+// ErrLimitsUnsupported is.
+var ErrFifoUnsupported = errors.New("execx: WithFifoIO is not supported on this platform")
+
+// fifoTriple is the set of named pipe paths backing one pipeline stage's
+// stdio when WithFifoIO is in effect.
+type fifoTriple struct {
+	stdin  string
+	stdout string
+	stderr string
+}
+
+// WithFifoIO switches this command's stdio from in-process pipes to named
+// FIFOs created under dir (one each for stdin, stdout, and stderr), the
+// containerd-shim style "stdio lives in files, not inherited fds" pattern.
+// Because the FIFOs live on disk at a known path instead of as anonymous
+// pipe fds, a process started this way can be detached from (the parent
+// exits) and later reattached to by opening the same paths, and it
+// survives an execx process restart. Output still flows through the usual
+// OnStdout/OnStderr/Events/tee writer chain; dir only changes the fd
+// plumbing underneath it. Call it before Start/Run so every stage is wired
+// up before the pipeline launches.
+//
+// Windows has no FIFO equivalent: there, Run/Start fails every stage with
+// ErrFifoUnsupported, the same way WithLimits fails with
+// ErrLimitsUnsupported on platforms without rlimits.
+// @group Streaming
+//
+// Example: with fifo io
+//
+//	dir, _ := os.MkdirTemp("", "execx-fifo")
+//	proc := execx.Command("go", "env", "GOOS").WithFifoIO(dir).Start()
+//	stdin, stdout, stderr := proc.StdioPaths()
+//	_, _ = proc.Wait()
+//	fmt.Println(stdin != "" && stdout != "" && stderr != "")
+//	// #bool true
+func (c *Cmd) WithFifoIO(dir string) *Cmd {
+	root := c.rootCmd()
+	root.fifoDir = dir
+	return c
+}
+
+// StdioPaths returns the filesystem paths of the FIFOs backing this
+// process's stdin, stdout, and stderr. All three are empty unless the
+// command was started with WithFifoIO on a platform that supports it.
+// @group Process
+//
+// Example: stdio paths
+//
+//	dir, _ := os.MkdirTemp("", "execx-fifo")
+//	proc := execx.Command("go", "env", "GOOS").WithFifoIO(dir).Start()
+//	_, stdout, _ := proc.StdioPaths()
+//	_, _ = proc.Wait()
+//	fmt.Println(stdout != "")
+//	// #bool true
+func (p *Process) StdioPaths() (stdin, stdout, stderr string) {
+	if p.pipeline == nil || len(p.pipeline.stages) == 0 {
+		return "", "", ""
+	}
+	triple := p.pipeline.stages[0].fifoPaths
+	if triple == nil {
+		return "", "", ""
+	}
+	return triple.stdin, triple.stdout, triple.stderr
+}
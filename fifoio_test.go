@@ -0,0 +1,52 @@
+package execx
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithFifoIO(t *testing.T) {
+	dir := t.TempDir()
+	proc := helperCommand("echo", "hi").WithFifoIO(dir).Start()
+	stdin, stdout, stderr := proc.StdioPaths()
+	res, err := proc.Wait()
+
+	if errors.Is(err, ErrFifoUnsupported) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if stdin == "" || stdout == "" || stderr == "" {
+		t.Fatalf("expected non-empty fifo paths, got %q %q %q", stdin, stdout, stderr)
+	}
+	if res.Stdout != "hi" {
+		t.Fatalf("expected stdout %q, got %q", "hi", res.Stdout)
+	}
+}
+
+func TestStdioPathsWithoutFifoIO(t *testing.T) {
+	proc := helperCommand("echo", "hi").Start()
+	stdin, stdout, stderr := proc.StdioPaths()
+	_, _ = proc.Wait()
+	if stdin != "" || stdout != "" || stderr != "" {
+		t.Fatalf("expected empty fifo paths without WithFifoIO, got %q %q %q", stdin, stdout, stderr)
+	}
+}
+
+func TestWithFifoIOPipelineStages(t *testing.T) {
+	dir := t.TempDir()
+	cmd := helperCommand("echo", "hi").WithFifoIO(dir)
+	helperPipe(cmd, "cat")
+	results, err := cmd.PipelineResults()
+
+	if errors.Is(err, ErrFifoUnsupported) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(results) != 2 || results[len(results)-1].Stdout != "hi" {
+		t.Fatalf("unexpected pipeline results: %+v", results)
+	}
+}
@@ -0,0 +1,142 @@
+//go:build unix
+
+package execx
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// makeFifoTriple creates the stdin/stdout/stderr named pipes for one stage
+// under dir, creating dir itself if needed.
+func makeFifoTriple(dir string) (*fifoTriple, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("execx: create fifo dir %s: %w", dir, err)
+	}
+	triple := &fifoTriple{
+		stdin:  filepath.Join(dir, "stdin"),
+		stdout: filepath.Join(dir, "stdout"),
+		stderr: filepath.Join(dir, "stderr"),
+	}
+	for _, path := range []string{triple.stdin, triple.stdout, triple.stderr} {
+		if err := syscall.Mkfifo(path, 0o600); err != nil && !os.IsExist(err) {
+			return nil, fmt.Errorf("execx: mkfifo %s: %w", path, err)
+		}
+	}
+	return triple, nil
+}
+
+// openFifoRDWR opens path O_RDWR regardless of which direction it's
+// actually used for. It's the standard trick for FIFOs whose peer may
+// open the other end later, or never (a path meant only for reattaching
+// to): a one-sided open(2) blocks until a peer with the opposite mode
+// shows up, which WithFifoIO can't guarantee.
+func openFifoRDWR(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_RDWR, 0)
+}
+
+// wireFifoStdio replaces stg's stdin/stdout/stderr with the named FIFOs
+// under dir. The child's stdin end is opened O_RDWR so the open never
+// blocks; for stdout/stderr the parent keeps a pure O_RDONLY end and hands
+// the child a pure O_WRONLY end (see openFifoPair), dropping its own copy
+// of that write end once the child has it (in start()) so a real EOF
+// still propagates once the child exits. Everything the
+// child writes is streamed into stdoutW/stderrW, the stage's normal writer
+// chain, so OnStdout/OnStderr/Events/tee keep working exactly as they do
+// over a regular pipe.
+//
+// chained reports whether the pipeline wiring is going to additionally
+// wrap this stage's cmd.Stdout in an io.MultiWriter feeding the next
+// stage's stdin (every stage but the last). In that case the parent's
+// write end of the stdout FIFO isn't a spare dup to drop once the child
+// has its own copy — it's one of the MultiWriter's own writers, used by
+// exec.Cmd's internal copy goroutine for the lifetime of the command — so
+// it's stashed on stg.fifoStdoutWrite for the pipeline's wait() to close
+// only after the stage's Wait() returns, instead of in start().
+func wireFifoStdio(stg *stage, dir string, stdoutW, stderrW io.Writer, chained bool) error {
+	triple, err := makeFifoTriple(dir)
+	if err != nil {
+		return err
+	}
+	stg.fifoPaths = triple
+
+	stdin, err := openFifoRDWR(triple.stdin)
+	if err != nil {
+		return err
+	}
+	stg.cmd.Stdin = stdin
+	stg.fifoFiles = append(stg.fifoFiles, stdin)
+	if stg.def.stdin != nil {
+		stdinW, err := os.OpenFile(triple.stdin, os.O_WRONLY, 0)
+		if err != nil {
+			return err
+		}
+		go func() {
+			_, _ = io.Copy(stdinW, stg.def.stdin)
+			_ = stdinW.Close()
+		}()
+	}
+
+	stdoutR, stdoutW2, err := openFifoPair(triple.stdout)
+	if err != nil {
+		return err
+	}
+	stg.cmd.Stdout = stdoutW2
+	stg.fifoFiles = append(stg.fifoFiles, stdoutR)
+	if chained {
+		stg.fifoStdoutWrite = stdoutW2
+	} else {
+		stg.fifoWriteEnds = append(stg.fifoWriteEnds, stdoutW2)
+	}
+	stg.fifoDone.Add(1)
+	go func() {
+		defer stg.fifoDone.Done()
+		_, _ = io.Copy(stdoutW, stdoutR)
+	}()
+
+	stderrR, stderrW2, err := openFifoPair(triple.stderr)
+	if err != nil {
+		return err
+	}
+	stg.cmd.Stderr = stderrW2
+	stg.fifoFiles = append(stg.fifoFiles, stderrR)
+	stg.fifoWriteEnds = append(stg.fifoWriteEnds, stderrW2)
+	stg.fifoDone.Add(1)
+	go func() {
+		defer stg.fifoDone.Done()
+		_, _ = io.Copy(stderrW, stderrR)
+	}()
+
+	return nil
+}
+
+// openFifoPair opens path for reading (kept by the parent to stream into
+// the writer chain) and then for writing (handed to the child as its
+// Stdout/Stderr). A bare O_RDONLY open would block until a writer shows
+// up, and a bare O_WRONLY open would block until a reader shows up, so
+// opening both ends from this single process needs a throwaway RDWR
+// handle to give the first open a peer; it's closed immediately, leaving
+// read as pure O_RDONLY and write as pure O_WRONLY. That matters once the
+// child exits and write is closed: a read side that were itself RDWR
+// would count as its own writer and never see EOF.
+func openFifoPair(path string) (read, write *os.File, err error) {
+	tmp, err := openFifoRDWR(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tmp.Close()
+
+	read, err = os.OpenFile(path, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	write, err = os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		_ = read.Close()
+		return nil, nil, err
+	}
+	return read, write, nil
+}
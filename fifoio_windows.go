@@ -0,0 +1,12 @@
+//go:build windows
+
+package execx
+
+import "io"
+
+// wireFifoStdio fails every stage with ErrFifoUnsupported: Windows has no
+// FIFO equivalent. WithFifoIO does not fall back to regular pipes here:
+// the stage's setupErr surfaces ErrFifoUnsupported through Result.Err.
+func wireFifoStdio(stg *stage, dir string, stdoutW, stderrW io.Writer, chained bool) error {
+	return ErrFifoUnsupported
+}
@@ -0,0 +1,96 @@
+// Package wire implements the minimal length-prefixed framing protocol
+// shared by execx's remote client (see the root package's Remote/CommandOn)
+// and the execx/agent server. It intentionally avoids pulling in gRPC or
+// protobuf so the module keeps zero external dependencies: each message is
+// a big-endian uint32 byte length followed by a JSON payload.
+package wire
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"io"
+)
+
+// StageSpec describes one command in a pipeline sent to a remote agent.
+type StageSpec struct {
+	Name string   `json:"name"`
+	Args []string `json:"args"`
+	Env  []string `json:"env"`
+	Dir  string   `json:"dir"`
+}
+
+// Request is the initial message a client sends to run a pipeline.
+type Request struct {
+	Stages   []StageSpec `json:"stages"`
+	PipeMode int         `json:"pipe_mode"`
+	Stdin    []byte      `json:"stdin,omitempty"`
+}
+
+// StageResult mirrors the fields of execx.Result that travel over the wire.
+type StageResult struct {
+	Stdout   string `json:"stdout"`
+	Stderr   string `json:"stderr"`
+	ExitCode int    `json:"exit_code"`
+	Err      string `json:"err,omitempty"`
+}
+
+// Response is the final message a server sends once every stage exits.
+type Response struct {
+	Results []StageResult `json:"results"`
+	Err     string        `json:"err,omitempty"`
+}
+
+// StreamKind distinguishes the kinds of frames multiplexed on a connection
+// after the initial Request, while the pipeline is running.
+type StreamKind int
+
+const (
+	StreamStdout StreamKind = iota
+	StreamStderr
+	StreamSignal
+)
+
+// StreamFrame carries incremental stage output, or (client to server) a
+// signal to forward to every running stage.
+type StreamFrame struct {
+	Kind   StreamKind `json:"kind"`
+	Stage  int        `json:"stage"`
+	Data   []byte     `json:"data,omitempty"`
+	Signal int        `json:"signal,omitempty"`
+}
+
+// Envelope multiplexes Request/StreamFrame/Response messages over one
+// connection so the reader can tell them apart without a side channel.
+type Envelope struct {
+	Request  *Request     `json:"request,omitempty"`
+	Stream   *StreamFrame `json:"stream,omitempty"`
+	Response *Response    `json:"response,omitempty"`
+}
+
+// WriteMessage writes v to w as a length-prefixed JSON frame.
+func WriteMessage(w io.Writer, v any) error {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(payload)))
+	if _, err := w.Write(length[:]); err != nil {
+		return err
+	}
+	_, err = w.Write(payload)
+	return err
+}
+
+// ReadMessage reads one length-prefixed JSON frame from r into v.
+func ReadMessage(r io.Reader, v any) error {
+	var length [4]byte
+	if _, err := io.ReadFull(r, length[:]); err != nil {
+		return err
+	}
+	payload := make([]byte, binary.BigEndian.Uint32(length[:]))
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return err
+	}
+	return json.Unmarshal(payload, v)
+}
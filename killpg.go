@@ -0,0 +1,7 @@
+package execx
+
+import "errors"
+
+// ErrProcessGroupUnsupported is returned by KillProcessGroup on platforms
+// without POSIX process groups.
+var ErrProcessGroupUnsupported = errors.New("execx: KillProcessGroup is not supported on this platform")
@@ -0,0 +1,36 @@
+package execx
+
+import (
+	"errors"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestKillProcessGroup(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("process groups not supported on windows")
+	}
+	proc := helperCommand("sleep", "200").Setpgid(true).Start()
+	if err := proc.KillProcessGroup(os.Kill); err != nil {
+		t.Fatalf("KillProcessGroup: %v", err)
+	}
+	res, _ := proc.Wait()
+	if !res.IsKilled() {
+		t.Fatalf("expected IsKilled, got %v", res.signal)
+	}
+}
+
+func TestKillProcessGroupUnsupportedOnWindows(t *testing.T) {
+	if runtime.GOOS != "windows" {
+		t.Skip("ErrProcessGroupUnsupported only returned on windows")
+	}
+	proc := helperCommand("sleep", "1").Start()
+	err := proc.KillProcessGroup(os.Kill)
+	if !errors.Is(err, ErrProcessGroupUnsupported) {
+		t.Fatalf("expected ErrProcessGroupUnsupported, got %v", err)
+	}
+	proc.KillAfter(10 * time.Millisecond)
+	_, _ = proc.Wait()
+}
@@ -0,0 +1,35 @@
+//go:build unix
+
+package execx
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// KillProcessGroup sends sig to every stage's process group (-pgid)
+// rather than just the direct child, tearing down any descendants the
+// child itself forked into the same group. This only targets the right
+// processes for a Cmd started with Setpgid(true), which makes each
+// stage its own process group leader (pgid == pid); without that, a
+// stage's pgid is inherited from this process, and signaling -pid would
+// hit the wrong group.
+// @group Process
+//
+// Example: kill process group
+//
+//	proc := execx.Command("sleep", "2").Setpgid(true).Start()
+//	_ = proc.KillProcessGroup(os.Kill)
+//	res, err := proc.Wait()
+//	fmt.Println(err != nil || res.ExitCode != 0)
+//	// #bool true
+func (p *Process) KillProcessGroup(sig os.Signal) error {
+	sysSig, ok := sig.(syscall.Signal)
+	if !ok {
+		return fmt.Errorf("execx: KillProcessGroup: unsupported signal %v", sig)
+	}
+	return p.signalAll(sig, func(proc *os.Process) error {
+		return syscall.Kill(-proc.Pid, sysSig)
+	})
+}
@@ -0,0 +1,24 @@
+//go:build windows
+
+package execx
+
+import (
+	"os"
+)
+
+// KillProcessGroup always fails with ErrProcessGroupUnsupported: Windows
+// has no -pgid kill primitive, and CREATE_NEW_PROCESS_GROUP plus
+// GenerateConsoleCtrlEvent isn't an equivalent execx can apply generically
+// (it only delivers Ctrl+Break, not an arbitrary signal), so this doesn't
+// silently no-op like Setpgid does.
+// @group Process
+//
+// Example: kill process group unsupported
+//
+//	proc := execx.Command("cmd", "/c", "exit", "0").Start()
+//	err := proc.KillProcessGroup(os.Kill)
+//	fmt.Println(errors.Is(err, execx.ErrProcessGroupUnsupported))
+//	// #bool true
+func (p *Process) KillProcessGroup(sig os.Signal) error {
+	return ErrProcessGroupUnsupported
+}
@@ -0,0 +1,38 @@
+package execx
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrLimitsUnsupported is returned when WithLimits is used on a platform
+// that cannot enforce resource limits before exec.
+var ErrLimitsUnsupported = errors.New("execx: WithLimits is not supported on this platform")
+
+// Limits caps resources for a child process. A zero value for any field
+// leaves that resource unbounded.
+type Limits struct {
+	MemoryBytes uint64        // address space limit, in bytes
+	CPUTime     time.Duration // CPU time limit
+	FileSize    uint64        // max size of files the process may write, in bytes
+	OpenFiles   uint64        // max open file descriptors
+	Processes   uint64        // max number of processes/threads for the user
+}
+
+// WithLimits caps the resources a command may consume. On Unix the limits
+// are applied before exec; on unsupported platforms the command fails to
+// start with ErrLimitsUnsupported, surfaced through Result.Err.
+// @group OS Controls
+//
+// Example: with limits
+//
+//	res, err := execx.Command("go", "env", "GOOS").
+//		WithLimits(execx.Limits{OpenFiles: 64}).
+//		Run()
+//	fmt.Println(err == nil || res.LimitExceeded != "")
+//	// #bool true
+func (c *Cmd) WithLimits(limits Limits) *Cmd {
+	l := limits
+	c.limits = &l
+	return c
+}
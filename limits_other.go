@@ -0,0 +1,17 @@
+//go:build !unix
+
+package execx
+
+import "os"
+
+func limitsSupported() error {
+	return ErrLimitsUnsupported
+}
+
+func wrapForLimits(name string, args []string, _ Limits) (string, []string) {
+	return name, args
+}
+
+func limitExceeded(_ os.Signal, _ *Limits) string {
+	return ""
+}
@@ -0,0 +1,25 @@
+package execx
+
+import "testing"
+
+func TestWithLimits(t *testing.T) {
+	res, err := helperCommand("echo", "hi").WithLimits(Limits{OpenFiles: 64}).Run()
+	if limitsSupported() == nil {
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if res.ExitCode != 0 {
+			t.Fatalf("expected clean exit, got %d", res.ExitCode)
+		}
+		return
+	}
+	if err == nil {
+		t.Fatalf("expected ErrLimitsUnsupported on this platform")
+	}
+}
+
+func TestLimitExceededNoSignal(t *testing.T) {
+	if got := limitExceeded(nil, &Limits{MemoryBytes: 1}); got != "" {
+		t.Fatalf("expected empty classification for nil signal, got %q", got)
+	}
+}
@@ -0,0 +1,61 @@
+//go:build unix
+
+package execx
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+)
+
+func limitsSupported() error {
+	return nil
+}
+
+// wrapForLimits rewrites argv to apply Limits via the shell's ulimit
+// builtin before exec'ing the real command, since os/exec has no pre-exec
+// hook to call setrlimit on the child directly.
+func wrapForLimits(name string, args []string, l Limits) (string, []string) {
+	var script strings.Builder
+	if l.MemoryBytes > 0 {
+		fmt.Fprintf(&script, "ulimit -v %d; ", l.MemoryBytes/1024)
+	}
+	if l.CPUTime > 0 {
+		fmt.Fprintf(&script, "ulimit -t %d; ", int64(l.CPUTime/time.Second))
+	}
+	if l.FileSize > 0 {
+		fmt.Fprintf(&script, "ulimit -f %d; ", l.FileSize/512)
+	}
+	if l.OpenFiles > 0 {
+		fmt.Fprintf(&script, "ulimit -n %d; ", l.OpenFiles)
+	}
+	if l.Processes > 0 {
+		fmt.Fprintf(&script, "ulimit -u %d; ", l.Processes)
+	}
+	script.WriteString(`exec "$0" "$@"`)
+
+	wrapped := append([]string{name}, args...)
+	return "/bin/sh", append([]string{"-c", script.String()}, wrapped...)
+}
+
+// limitExceeded names the limit most likely responsible for a signal-based
+// termination, so callers using KillAfter/WithTimeout can tell resource
+// kills apart from timeout kills.
+func limitExceeded(sig os.Signal, l *Limits) string {
+	if l == nil || sig == nil {
+		return ""
+	}
+	switch sig {
+	case syscall.SIGXCPU:
+		return "cpu_time"
+	case syscall.SIGXFSZ:
+		return "file_size"
+	case syscall.SIGKILL:
+		if l.MemoryBytes > 0 {
+			return "memory"
+		}
+	}
+	return ""
+}
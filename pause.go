@@ -0,0 +1,24 @@
+package execx
+
+// Paused reports whether the process was most recently suspended with
+// Pause (and not since resumed with Resume).
+// @group Process
+//
+// Example: paused
+//
+//	proc := execx.Command("sleep", "2").Start()
+//	_ = proc.Pause()
+//	fmt.Println(proc.Paused())
+//	_ = proc.Resume()
+//	// #bool true
+func (p *Process) Paused() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.paused
+}
+
+func (p *Process) setPaused(paused bool) {
+	p.mu.Lock()
+	p.paused = paused
+	p.mu.Unlock()
+}
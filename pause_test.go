@@ -0,0 +1,24 @@
+package execx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPauseResume(t *testing.T) {
+	proc := helperCommand("sleep", "200").Start()
+	if err := proc.Pause(); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	if !proc.Paused() {
+		t.Fatalf("expected process to report paused")
+	}
+	if err := proc.Resume(); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if proc.Paused() {
+		t.Fatalf("expected process to report resumed")
+	}
+	proc.KillAfter(10 * time.Millisecond)
+	_, _ = proc.Wait()
+}
@@ -0,0 +1,48 @@
+//go:build unix
+
+package execx
+
+import (
+	"os"
+	"syscall"
+)
+
+// Pause suspends every stage of the pipeline with SIGSTOP.
+// @group Process
+//
+// Example: pause
+//
+//	proc := execx.Command("sleep", "2").Start()
+//	_ = proc.Pause()
+//	fmt.Println(proc.Paused())
+//	_ = proc.Resume()
+//	// #bool true
+func (p *Process) Pause() error {
+	err := p.signalAll(syscall.SIGSTOP, func(proc *os.Process) error {
+		return proc.Signal(syscall.SIGSTOP)
+	})
+	if err == nil {
+		p.setPaused(true)
+	}
+	return err
+}
+
+// Resume continues every stage of the pipeline with SIGCONT.
+// @group Process
+//
+// Example: resume
+//
+//	proc := execx.Command("sleep", "2").Start()
+//	_ = proc.Pause()
+//	_ = proc.Resume()
+//	fmt.Println(proc.Paused())
+//	// #bool false
+func (p *Process) Resume() error {
+	err := p.signalAll(syscall.SIGCONT, func(proc *os.Process) error {
+		return proc.Signal(syscall.SIGCONT)
+	})
+	if err == nil {
+		p.setPaused(false)
+	}
+	return err
+}
@@ -0,0 +1,84 @@
+//go:build windows
+
+package execx
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+const processSuspendResume = 0x0800
+
+var (
+	ntdll                = syscall.NewLazyDLL("ntdll.dll")
+	procNtSuspendProcess = ntdll.NewProc("NtSuspendProcess")
+	procNtResumeProcess  = ntdll.NewProc("NtResumeProcess")
+)
+
+// pauseSignal labels the NT suspend/resume calls as an os.Signal purely so
+// Pause and Resume can report an EventSignal like every other signal path.
+type pauseSignal string
+
+func (s pauseSignal) String() string { return string(s) }
+func (s pauseSignal) Signal()        {}
+
+func ntToggle(proc *os.Process, suspend bool) error {
+	handle, err := syscall.OpenProcess(processSuspendResume, false, uint32(proc.Pid))
+	if err != nil {
+		return err
+	}
+	defer syscall.CloseHandle(handle)
+
+	call := procNtResumeProcess
+	name := "NtResumeProcess"
+	if suspend {
+		call = procNtSuspendProcess
+		name = "NtSuspendProcess"
+	}
+	ret, _, _ := call.Call(uintptr(handle))
+	if ret != 0 {
+		return fmt.Errorf("execx: %s failed: 0x%x", name, ret)
+	}
+	return nil
+}
+
+// Pause suspends every stage of the pipeline via NtSuspendProcess.
+// @group Process
+//
+// Example: pause
+//
+//	proc := execx.Command("sleep", "2").Start()
+//	_ = proc.Pause()
+//	fmt.Println(proc.Paused())
+//	_ = proc.Resume()
+//	// #bool true
+func (p *Process) Pause() error {
+	err := p.signalAll(pauseSignal("SIGSTOP"), func(proc *os.Process) error {
+		return ntToggle(proc, true)
+	})
+	if err == nil {
+		p.setPaused(true)
+	}
+	return err
+}
+
+// Resume continues every stage of the pipeline via NtResumeProcess.
+// @group Process
+//
+// Example: resume
+//
+//	proc := execx.Command("sleep", "2").Start()
+//	_ = proc.Pause()
+//	_ = proc.Resume()
+//	fmt.Println(proc.Paused())
+//	// #bool false
+func (p *Process) Resume() error {
+	err := p.signalAll(pauseSignal("SIGCONT"), func(proc *os.Process) error {
+		return ntToggle(proc, false)
+	})
+	if err == nil {
+		p.setPaused(false)
+	}
+	return err
+}
@@ -4,39 +4,81 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"sync"
 	"time"
 )
 
 type stage struct {
-	cmd         *exec.Cmd
-	def         *Cmd
-	stdoutBuf   bytes.Buffer
-	stderrBuf   bytes.Buffer
-	combinedBuf bytes.Buffer
-	startErr    error
-	setupErr    error
-	waitErr     error
-	startTime   time.Time
-	pipeWriter  *io.PipeWriter
-	ptyMaster   *os.File
-	ptySlave    *os.File
-	ptyWriter   io.Writer
-	ptyDone     chan error
+	cmd             *exec.Cmd
+	def             *Cmd
+	stdoutBuf       bytes.Buffer
+	stderrBuf       bytes.Buffer
+	combinedBuf     bytes.Buffer
+	startErr        error
+	setupErr        error
+	waitErr         error
+	startTime       time.Time
+	spawnTime       time.Time
+	pipeWriter      *io.PipeWriter
+	ptyMaster       *os.File
+	ptySlave        *os.File
+	ptyWriter       io.Writer
+	ptyFlush        func()
+	ptyDone         chan error
+	fifoPaths       *fifoTriple
+	fifoFiles       []*os.File
+	fifoWriteEnds   []*os.File
+	fifoStdoutWrite *os.File
+	fifoDone        sync.WaitGroup
 }
 
 type pipeline struct {
-	stages       []*stage
-	withCombined bool
+	stages        []*stage
+	withCombined  bool
+	root          *Cmd
+	cachedResults []Result
+	ptyRestore    func() error
 }
 
-func (c *Cmd) newPipeline(withCombined bool, shadow *shadowContext) *pipeline {
+func (c *Cmd) newPipeline(withCombined bool) *pipeline {
+	root := c.rootCmd()
 	stages := c.pipelineStages()
-	for _, stage := range stages {
+	for i, stage := range stages {
 		stage.startTime = time.Now()
+		if root.eventsChan != nil || len(root.eventSinks) > 0 {
+			sink := stage.def.ensureEvents()
+			sink.stageIndex = i
+			sink.channel = root.eventsChan
+			sink.sinks = root.eventSinks
+		}
+		if stage.def.events != nil {
+			stage.def.events.start = stage.startTime
+		}
+		if stage.def.limits != nil {
+			if err := limitsSupported(); err != nil {
+				stage.setupErr = err
+			}
+		}
+		if stage.def.sandbox != nil {
+			if err := sandboxSupported(); err != nil {
+				stage.setupErr = err
+			}
+		}
+		if err := stage.def.compileRoutes(); err != nil && stage.setupErr == nil {
+			stage.setupErr = err
+		}
+		if stage.def.router != nil && stage.def.router.compileErr != nil && stage.setupErr == nil {
+			stage.setupErr = stage.def.router.compileErr
+		}
 		stage.cmd = stage.def.execCmd()
+		if stage.setupErr != nil {
+			continue
+		}
 		if stage.def.rootCmd().usePTY {
 			master, slave, err := openPTYFunc()
 			if err != nil {
@@ -45,12 +87,25 @@ func (c *Cmd) newPipeline(withCombined bool, shadow *shadowContext) *pipeline {
 			}
 			stage.ptyMaster = master
 			stage.ptySlave = slave
-			stage.ptyWriter = stage.def.ptyWriter(&stage.stdoutBuf, withCombined, &stage.combinedBuf, shadow)
+			stage.ptyWriter, stage.ptyFlush = stage.def.ptyWriter(&stage.stdoutBuf, withCombined, &stage.combinedBuf, i, stage.cmd)
+			stage.cmd.Stdin = slave
 			stage.cmd.Stdout = slave
 			stage.cmd.Stderr = slave
+			attachPTYSession(stage.cmd, slave)
+			if root.ptyRows != 0 || root.ptyCols != 0 {
+				_ = ptySetWinsize(master, root.ptyRows, root.ptyCols)
+			}
+		} else if dir := stage.def.rootCmd().fifoDir; dir != "" {
+			stdoutWriter := stage.def.stdoutWriter(&stage.stdoutBuf, withCombined, &stage.combinedBuf, i, stage.cmd)
+			stderrWriter := stage.def.stderrWriter(&stage.stderrBuf, withCombined, &stage.combinedBuf, i, stage.cmd)
+			stageDir := filepath.Join(dir, fmt.Sprintf("stage%d", i))
+			chained := i < len(stages)-1
+			if err := wireFifoStdio(stage, stageDir, stdoutWriter, stderrWriter, chained); err != nil {
+				stage.setupErr = err
+			}
 		} else {
-			stdoutWriter := stage.def.stdoutWriter(&stage.stdoutBuf, withCombined, &stage.combinedBuf, shadow)
-			stderrWriter := stage.def.stderrWriter(&stage.stderrBuf, withCombined, &stage.combinedBuf, shadow)
+			stdoutWriter := stage.def.stdoutWriter(&stage.stdoutBuf, withCombined, &stage.combinedBuf, i, stage.cmd)
+			stderrWriter := stage.def.stderrWriter(&stage.stderrBuf, withCombined, &stage.combinedBuf, i, stage.cmd)
 			stage.cmd.Stdout = stdoutWriter
 			stage.cmd.Stderr = stderrWriter
 		}
@@ -58,7 +113,9 @@ func (c *Cmd) newPipeline(withCombined bool, shadow *shadowContext) *pipeline {
 
 	for i := range stages {
 		if i == 0 {
-			stages[i].cmd.Stdin = stages[i].def.stdin
+			if stages[i].cmd.Stdin == nil {
+				stages[i].cmd.Stdin = stages[i].def.stdin
+			}
 			continue
 		}
 		reader, writer := io.Pipe()
@@ -67,7 +124,7 @@ func (c *Cmd) newPipeline(withCombined bool, shadow *shadowContext) *pipeline {
 		stages[i-1].cmd.Stdout = io.MultiWriter(stages[i-1].cmd.Stdout, writer)
 	}
 
-	return &pipeline{stages: stages, withCombined: withCombined}
+	return &pipeline{stages: stages, withCombined: withCombined, root: root}
 }
 
 func (p *pipeline) start() {
@@ -76,6 +133,9 @@ func (p *pipeline) start() {
 			stg.startErr = stg.setupErr
 			break
 		}
+		if sink := stg.def.events; sink != nil {
+			sink.emit(Event{Kind: EventBefore, Message: "starting"})
+		}
 		stg.startErr = stg.cmd.Start()
 		if stg.startErr != nil {
 			if stg.ptyMaster != nil {
@@ -89,10 +149,23 @@ func (p *pipeline) start() {
 			}
 			break
 		}
+		stg.spawnTime = time.Now()
+		if stg.cmd.Process != nil {
+			registerOwnedPid(stg.cmd.Process.Pid)
+		}
+		if sink := stg.def.events; sink != nil && stg.cmd.Process != nil {
+			sink.emit(Event{Kind: EventStarted, Pid: stg.cmd.Process.Pid, Message: "started"})
+		}
+		for _, f := range stg.fifoWriteEnds {
+			_ = f.Close()
+		}
 		if stg.ptyMaster != nil {
 			stg.ptyDone = make(chan error, 1)
 			go func(st *stage) {
 				_, err := io.Copy(st.ptyWriter, st.ptyMaster)
+				if st.ptyFlush != nil {
+					st.ptyFlush()
+				}
 				if err != nil {
 					st.ptyDone <- err
 				} else {
@@ -101,6 +174,17 @@ func (p *pipeline) start() {
 				_ = st.ptyMaster.Close()
 			}(stg)
 			_ = stg.ptySlave.Close()
+
+			if r := p.root.ptyStdin; r != nil {
+				go func(st *stage, r io.Reader) {
+					_, _ = io.Copy(st.ptyMaster, r)
+				}(stg, r)
+			}
+			if p.root.ptyRawMode && p.ptyRestore == nil {
+				if restore, err := ptyMakeRaw(os.Stdin); err == nil {
+					p.ptyRestore = restore
+				}
+			}
 		}
 	}
 }
@@ -114,6 +198,9 @@ func (p *pipeline) wait() {
 			continue
 		}
 		p.stages[i].waitErr = p.stages[i].cmd.Wait()
+		if p.stages[i].cmd.Process != nil {
+			unregisterOwnedPid(p.stages[i].cmd.Process.Pid)
+		}
 		if p.stages[i].pipeWriter != nil {
 			_ = p.stages[i].pipeWriter.Close()
 		}
@@ -122,17 +209,51 @@ func (p *pipeline) wait() {
 				p.stages[i].waitErr = err
 			}
 		}
+		if p.stages[i].fifoStdoutWrite != nil {
+			// Close the write end now that Wait has confirmed exec's own
+			// copy goroutine is done with it, so the stage's fifo-reading
+			// goroutine below sees EOF instead of blocking forever.
+			_ = p.stages[i].fifoStdoutWrite.Close()
+		}
+		p.stages[i].fifoDone.Wait()
+		for _, f := range p.stages[i].fifoFiles {
+			_ = f.Close()
+		}
+	}
+	if p.ptyRestore != nil {
+		_ = p.ptyRestore()
+		p.ptyRestore = nil
 	}
 }
 
 func (p *pipeline) results() []Result {
+	if p.cachedResults != nil {
+		return p.cachedResults
+	}
 	results := make([]Result, 0, len(p.stages))
 	for _, stage := range p.stages {
 		results = append(results, stage.result())
 	}
+	p.cachedResults = results
 	return results
 }
 
+// closeEvents emits EventPipelineDone to every sink registered with
+// WithEventSink and, if Events was ever called for this pipeline, sends it
+// on the channel returned by Events and closes that channel exactly once.
+func (p *pipeline) closeEvents() {
+	done := Event{Kind: EventPipelineDone, Stage: -1, Results: p.results()}
+	for _, sink := range p.root.eventSinks {
+		_ = sink.Emit(done)
+	}
+	ch := p.root.eventsChan
+	if ch == nil {
+		return
+	}
+	ch.send(done)
+	ch.close()
+}
+
 func (p *pipeline) primaryResult(mode pipeMode) (Result, string) {
 	results := p.results()
 	primaryIndex := len(results) - 1
@@ -170,10 +291,13 @@ func (s *stage) result() Result {
 		Duration: time.Since(s.startTime),
 	}
 	if s.startErr != nil {
+		class := classifyStartErr(s.startErr)
+		res.ExitCode = startupExitCode(class)
 		res.Err = ErrExec{
 			Err:      s.startErr,
-			ExitCode: -1,
+			ExitCode: res.ExitCode,
 			Stderr:   res.Stderr,
+			Class:    class,
 		}
 		return res
 	}
@@ -188,6 +312,18 @@ func (s *stage) result() Result {
 	if s.cmd.ProcessState != nil {
 		res.ExitCode = s.cmd.ProcessState.ExitCode()
 		res.signal = signalFromState(s.cmd.ProcessState)
+		res.Rusage = rusageFromState(s.cmd.ProcessState)
+		res.processState = s.cmd.ProcessState
+		res.coreDumped = coreDumpedFromState(s.cmd.ProcessState)
+		res.stopped, res.stopSignal = stoppedFromState(s.cmd.ProcessState)
+	}
+	if !s.spawnTime.IsZero() {
+		res.ElapsedWall = time.Since(s.spawnTime)
+	}
+	res.LimitExceeded = limitExceeded(res.signal, s.def.limits)
+	s.recordExitEvent(&res)
+	if s.def.onMetrics != nil {
+		s.def.onMetrics(res.Rusage)
 	}
 	return res
 }
@@ -0,0 +1,171 @@
+package execx
+
+import (
+	"errors"
+	"io"
+	"os"
+)
+
+// ErrNoPTY is returned by Resize when the process has no pseudo-terminal
+// attached to resize.
+var ErrNoPTY = errors.New("execx: no pty attached to this process")
+
+// openPTYFunc opens a new pseudo-terminal master/slave pair. It is a var
+// so tests can inject failures without requiring a real PTY device.
+var openPTYFunc = openPTY
+
+// PTYOption configures a command before WithPTY allocates its
+// pseudo-terminal.
+type PTYOption func(*Cmd)
+
+// WithPTYSize sets the terminal window size applied to the
+// pseudo-terminal as soon as it is opened, equivalent to calling PTYSize
+// directly. Use it to size the PTY inline with WithPTY.
+// @group PTY
+//
+// Example: with pty size
+//
+//	res, _ := execx.Command("go", "env", "GOOS").
+//		WithPTY(execx.WithPTYSize(24, 80)).
+//		Run()
+//	fmt.Println(res.ExitCode == 0)
+//	// #bool true
+func WithPTYSize(rows, cols uint16) PTYOption {
+	return func(c *Cmd) { c.PTYSize(rows, cols) }
+}
+
+// WithPTY attaches the command's stdin, stdout, and stderr to a
+// pseudo-terminal instead of pipes, so programs that check isatty or
+// rely on terminal line discipline behave as they would run
+// interactively. It opens the PTY via openPTY (ptmx-based on Linux, the
+// BSD-ioctl pair on darwin) when the stage starts, makes the child the
+// session leader with the slave as its controlling terminal, and returns
+// a clear error from Run/Start/Wait on platforms without PTY support.
+// Combine with PTYSize, PTYStdin, and PTYRawMode to configure the
+// terminal further, or pass PTYOptions inline.
+// @group PTY
+//
+// Example: with pty
+//
+//	res, _ := execx.Command("go", "env", "GOOS").
+//		WithPTY().
+//		Run()
+//	fmt.Println(res.ExitCode == 0)
+//	// #bool true
+func (c *Cmd) WithPTY(opts ...PTYOption) *Cmd {
+	root := c.rootCmd()
+	root.usePTY = true
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// PTYMaster returns the master side of the pseudo-terminal for the
+// first PTY-backed stage, or nil if the command wasn't started with
+// WithPTY. Safe to call as soon as Start returns.
+// @group PTY
+//
+// Example: pty master
+//
+//	proc := execx.Command("go", "env", "GOOS").WithPTY().Start()
+//	master := proc.PTYMaster()
+//	_, _ = proc.Wait()
+//	fmt.Println(master != nil)
+//	// #bool true
+func (p *Process) PTYMaster() *os.File {
+	if p == nil || p.pipeline == nil {
+		return nil
+	}
+	for _, stage := range p.pipeline.stages {
+		if stage != nil && stage.ptyMaster != nil {
+			return stage.ptyMaster
+		}
+	}
+	return nil
+}
+
+// PTYSize sets the terminal window size applied to the pseudo-terminal as
+// soon as it is opened. It has no effect on a command that isn't attached
+// to a PTY.
+// @group PTY
+//
+// Example: pty size
+//
+//	res, _ := execx.Command("go", "env", "GOOS").PTYSize(24, 80).Run()
+//	fmt.Println(res.ExitCode == 0)
+//	// #bool true
+func (c *Cmd) PTYSize(rows, cols uint16) *Cmd {
+	root := c.rootCmd()
+	root.ptyRows = rows
+	root.ptyCols = cols
+	return c
+}
+
+// PTYStdin forwards r to the pseudo-terminal's master side for the
+// duration of the command, so interactive programs receive keystrokes the
+// way they would from a real terminal. It has no effect on a command that
+// isn't attached to a PTY.
+// @group PTY
+//
+// Example: pty stdin
+//
+//	res, _ := execx.Command("go", "env", "GOOS").PTYStdin(os.Stdin).Run()
+//	fmt.Println(res.ExitCode == 0)
+//	// #bool true
+func (c *Cmd) PTYStdin(r io.Reader) *Cmd {
+	root := c.rootCmd()
+	root.ptyStdin = r
+	return c
+}
+
+// PTYRawMode puts the controlling terminal into raw mode for the
+// duration of the command and restores the previous settings once it
+// exits, so interactive programs like vim or ssh see every keystroke
+// unprocessed instead of line-buffered and echoed. It has no effect on a
+// command that isn't attached to a PTY, or when stdin isn't a terminal.
+// @group PTY
+//
+// Example: pty raw mode
+//
+//	res, _ := execx.Command("go", "env", "GOOS").PTYRawMode().Run()
+//	fmt.Println(res.ExitCode == 0)
+//	// #bool true
+func (c *Cmd) PTYRawMode() *Cmd {
+	root := c.rootCmd()
+	root.ptyRawMode = true
+	return c
+}
+
+// Resize updates the pseudo-terminal window size for every PTY-backed
+// stage of the pipeline, issuing TIOCSWINSZ so the child sees a SIGWINCH.
+// Safe to call concurrently with Wait.
+// @group PTY
+//
+// Example: resize
+//
+//	proc := execx.Command("go", "env", "GOOS").Start()
+//	err := proc.Resize(40, 120)
+//	_, _ = proc.Wait()
+//	fmt.Println(err == execx.ErrNoPTY)
+//	// #bool true
+func (p *Process) Resize(rows, cols uint16) error {
+	if p == nil || p.pipeline == nil {
+		return errors.New("process not started")
+	}
+	count := 0
+	var firstErr error
+	for _, stage := range p.pipeline.stages {
+		if stage == nil || stage.ptyMaster == nil {
+			continue
+		}
+		count++
+		if err := ptySetWinsize(stage.ptyMaster, rows, cols); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if count == 0 && firstErr == nil {
+		return ErrNoPTY
+	}
+	return firstErr
+}
@@ -28,10 +28,10 @@ func TestPTYIoctlSuccessAndError(t *testing.T) {
 		t.Fatalf("open ptmx: %v", err)
 	}
 	defer master.Close()
-	if err := ptyIoctl(master.Fd(), syscall.TIOCPTYGRANT, 0); err != nil {
+	if err := ptyIoctl(master.Fd(), syscall.TIOCPTYGRANT, nil); err != nil {
 		t.Fatalf("expected ioctl success, got %v", err)
 	}
-	if err := ptyIoctl(0, 0, 0); err == nil {
+	if err := ptyIoctl(0, 0, nil); err == nil {
 		t.Fatalf("expected ioctl error")
 	}
 }
@@ -40,7 +40,7 @@ func TestOpenPTYWithOpenError(t *testing.T) {
 	openFile := func(string, int, os.FileMode) (*os.File, error) {
 		return nil, errors.New("open failed")
 	}
-	_, _, err := openPTYWith(openFile, func(uintptr, uintptr, uintptr) error { return nil })
+	_, _, err := openPTYWith(openFile, func(uintptr, uintptr, unsafe.Pointer) error { return nil })
 	if err == nil || err.Error() != "open failed" {
 		t.Fatalf("expected open error, got %v", err)
 	}
@@ -50,7 +50,7 @@ func TestOpenPTYWithGrantError(t *testing.T) {
 	openFile := func(string, int, os.FileMode) (*os.File, error) {
 		return os.OpenFile(os.DevNull, os.O_RDWR, 0)
 	}
-	_, _, err := openPTYWith(openFile, func(fd uintptr, req uintptr, arg uintptr) error {
+	_, _, err := openPTYWith(openFile, func(fd uintptr, req uintptr, arg unsafe.Pointer) error {
 		if req == syscall.TIOCPTYGRANT {
 			return errors.New("grant failed")
 		}
@@ -65,7 +65,7 @@ func TestOpenPTYWithUnlockError(t *testing.T) {
 	openFile := func(string, int, os.FileMode) (*os.File, error) {
 		return os.OpenFile(os.DevNull, os.O_RDWR, 0)
 	}
-	ioctl := func(fd uintptr, req uintptr, arg uintptr) error {
+	ioctl := func(fd uintptr, req uintptr, arg unsafe.Pointer) error {
 		if req == syscall.TIOCPTYUNLK {
 			return errors.New("unlock failed")
 		}
@@ -81,7 +81,7 @@ func TestOpenPTYWithNameError(t *testing.T) {
 	openFile := func(string, int, os.FileMode) (*os.File, error) {
 		return os.OpenFile(os.DevNull, os.O_RDWR, 0)
 	}
-	ioctl := func(fd uintptr, req uintptr, arg uintptr) error {
+	ioctl := func(fd uintptr, req uintptr, arg unsafe.Pointer) error {
 		if req == syscall.TIOCPTYGNAME {
 			return errors.New("name failed")
 		}
@@ -100,9 +100,9 @@ func TestOpenPTYWithSlaveError(t *testing.T) {
 		}
 		return nil, errors.New("slave open failed")
 	}
-	ioctl := func(fd uintptr, req uintptr, arg uintptr) error {
+	ioctl := func(fd uintptr, req uintptr, arg unsafe.Pointer) error {
 		if req == syscall.TIOCPTYGNAME {
-			buf := (*[128]byte)(unsafe.Pointer(arg))
+			buf := (*[128]byte)(arg)
 			copy(buf[:], []byte("/dev/doesnotexist"))
 		}
 		return nil
@@ -117,9 +117,9 @@ func TestOpenPTYWithSuccess(t *testing.T) {
 	openFile := func(name string, flag int, perm os.FileMode) (*os.File, error) {
 		return os.OpenFile(os.DevNull, os.O_RDWR, 0)
 	}
-	ioctl := func(fd uintptr, req uintptr, arg uintptr) error {
+	ioctl := func(fd uintptr, req uintptr, arg unsafe.Pointer) error {
 		if req == syscall.TIOCPTYGNAME {
-			buf := (*[128]byte)(unsafe.Pointer(arg))
+			buf := (*[128]byte)(arg)
 			copy(buf[:], []byte(os.DevNull))
 		}
 		return nil
@@ -131,3 +131,62 @@ func TestOpenPTYWithSuccess(t *testing.T) {
 	_ = master.Close()
 	_ = slave.Close()
 }
+
+func TestPtySetWinsizeDarwin(t *testing.T) {
+	master, slave, err := openPTY()
+	if err != nil {
+		t.Fatalf("openPTY: %v", err)
+	}
+	defer master.Close()
+	defer slave.Close()
+
+	if err := ptySetWinsize(master, 40, 120); err != nil {
+		t.Fatalf("ptySetWinsize: %v", err)
+	}
+
+	var ws winsize
+	if err := ptyIoctl(slave.Fd(), syscall.TIOCGWINSZ, unsafe.Pointer(&ws)); err != nil {
+		t.Fatalf("TIOCGWINSZ: %v", err)
+	}
+	if ws.row != 40 || ws.col != 120 {
+		t.Fatalf("expected 40x120, got %dx%d", ws.row, ws.col)
+	}
+}
+
+func TestPtyMakeRawRestoreDarwin(t *testing.T) {
+	master, slave, err := openPTY()
+	if err != nil {
+		t.Fatalf("openPTY: %v", err)
+	}
+	defer master.Close()
+	defer slave.Close()
+
+	var before syscall.Termios
+	if err := ptyIoctl(slave.Fd(), syscall.TIOCGETA, unsafe.Pointer(&before)); err != nil {
+		t.Fatalf("tiocgeta: %v", err)
+	}
+
+	restore, err := ptyMakeRaw(slave)
+	if err != nil {
+		t.Fatalf("ptyMakeRaw: %v", err)
+	}
+
+	var raw syscall.Termios
+	if err := ptyIoctl(slave.Fd(), syscall.TIOCGETA, unsafe.Pointer(&raw)); err != nil {
+		t.Fatalf("tiocgeta after raw: %v", err)
+	}
+	if raw.Lflag&syscall.ECHO != 0 || raw.Lflag&syscall.ICANON != 0 {
+		t.Fatalf("expected echo and canonical mode cleared, got lflag %#o", raw.Lflag)
+	}
+
+	if err := restore(); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	var after syscall.Termios
+	if err := ptyIoctl(slave.Fd(), syscall.TIOCGETA, unsafe.Pointer(&after)); err != nil {
+		t.Fatalf("tiocgeta after restore: %v", err)
+	}
+	if after.Lflag != before.Lflag {
+		t.Fatalf("expected lflag restored to %#o, got %#o", before.Lflag, after.Lflag)
+	}
+}
@@ -5,6 +5,7 @@ package execx
 import (
 	"fmt"
 	"os"
+	"os/exec"
 	"syscall"
 	"unsafe"
 )
@@ -17,19 +18,19 @@ func openPTY() (*os.File, *os.File, error) {
 	return openPTYWith(os.OpenFile, ptyIoctl)
 }
 
-func openPTYWith(openFile func(string, int, os.FileMode) (*os.File, error), ioctl func(uintptr, uintptr, uintptr) error) (*os.File, *os.File, error) {
+func openPTYWith(openFile func(string, int, os.FileMode) (*os.File, error), ioctl func(uintptr, uintptr, unsafe.Pointer) error) (*os.File, *os.File, error) {
 	master, err := openFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY, 0)
 	if err != nil {
 		return nil, nil, err
 	}
 	fd := master.Fd()
 	unlock := int32(0)
-	if err := ioctl(fd, syscall.TIOCSPTLCK, uintptr(unsafe.Pointer(&unlock))); err != nil {
+	if err := ioctl(fd, syscall.TIOCSPTLCK, unsafe.Pointer(&unlock)); err != nil {
 		_ = master.Close()
 		return nil, nil, err
 	}
 	var ptyNum uint32
-	if err := ioctl(fd, syscall.TIOCGPTN, uintptr(unsafe.Pointer(&ptyNum))); err != nil {
+	if err := ioctl(fd, syscall.TIOCGPTN, unsafe.Pointer(&ptyNum)); err != nil {
 		_ = master.Close()
 		return nil, nil, err
 	}
@@ -42,10 +43,94 @@ func openPTYWith(openFile func(string, int, os.FileMode) (*os.File, error), ioct
 	return master, slave, nil
 }
 
-func ptyIoctl(fd uintptr, req uintptr, arg uintptr) error {
-	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, req, arg)
+// ptyIoctl converts arg to a uintptr directly in the syscall call
+// expression, as go vet's unsafeptr check requires: the pointer must not
+// be round-tripped through a uintptr stored in a variable first.
+func ptyIoctl(fd uintptr, req uintptr, arg unsafe.Pointer) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, req, uintptr(arg))
 	if errno != 0 {
 		return errno
 	}
 	return nil
 }
+
+type winsize struct {
+	row, col, xpixel, ypixel uint16
+}
+
+// ptySetWinsize issues TIOCSWINSZ so the pty's controlling process sees a
+// SIGWINCH with the new terminal dimensions.
+func ptySetWinsize(f *os.File, rows, cols uint16) error {
+	ws := winsize{row: rows, col: cols}
+	return ptyIoctl(f.Fd(), syscall.TIOCSWINSZ, unsafe.Pointer(&ws))
+}
+
+// The amd64 build of the stdlib syscall package omits the termios ioctl
+// requests and c_iflag/c_oflag/c_lflag/c_cflag bit constants that
+// x/sys/unix exposes as unix.TCGETS etc.; these mirror glibc's
+// <asm-generic/termbits.h> so raw mode can be toggled without that
+// dependency.
+const (
+	tcgets = 0x5401
+	tcsets = 0x5402
+
+	iBrkint = 0x0002
+	iIcrnl  = 0x0100
+	iInpck  = 0x0010
+	iIstrip = 0x0020
+	iIxon   = 0x0400
+
+	oOpost = 0x0001
+
+	lEcho   = 0x0008
+	lIcanon = 0x0002
+	lIsig   = 0x0001
+	lIexten = 0x8000
+
+	cCsize  = 0x0030
+	cParenb = 0x0100
+	cCs8    = 0x0030
+
+	vmin  = 6
+	vtime = 5
+)
+
+// ptyMakeRaw puts f's line discipline into raw mode (no echo, no
+// canonical processing, one byte at a time) and returns a function that
+// restores the settings captured before the change.
+func ptyMakeRaw(f *os.File) (restore func() error, err error) {
+	fd := f.Fd()
+	var saved syscall.Termios
+	if err := ptyIoctl(fd, tcgets, unsafe.Pointer(&saved)); err != nil {
+		return nil, err
+	}
+	raw := saved
+	raw.Iflag &^= iBrkint | iIcrnl | iInpck | iIstrip | iIxon
+	raw.Oflag &^= oOpost
+	raw.Lflag &^= lEcho | lIcanon | lIsig | lIexten
+	raw.Cflag &^= cCsize | cParenb
+	raw.Cflag |= cCs8
+	raw.Cc[vmin] = 1
+	raw.Cc[vtime] = 0
+	if err := ptyIoctl(fd, tcsets, unsafe.Pointer(&raw)); err != nil {
+		return nil, err
+	}
+	return func() error {
+		return ptyIoctl(fd, tcsets, unsafe.Pointer(&saved))
+	}, nil
+}
+
+// attachPTYSession configures cmd to make slave its controlling terminal,
+// starting it in a new session so TIOCSCTTY attaches on exec, the way a
+// real terminal emulator starts a shell.
+func attachPTYSession(cmd *exec.Cmd, slave *os.File) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setsid = true
+	cmd.SysProcAttr.Setctty = true
+	// Ctty indexes the child's post-exec attr.Files, not the parent's raw
+	// fd number; slave is wired up as stdin/stdout/stderr (index 0) by
+	// the pipeline before this is called.
+	cmd.SysProcAttr.Ctty = 0
+}
@@ -29,10 +29,10 @@ func TestPTYIoctlSuccessAndErrorLinux(t *testing.T) {
 	}
 	defer master.Close()
 	unlock := int32(0)
-	if err := ptyIoctl(master.Fd(), syscall.TIOCSPTLCK, uintptr(unsafe.Pointer(&unlock))); err != nil {
+	if err := ptyIoctl(master.Fd(), syscall.TIOCSPTLCK, unsafe.Pointer(&unlock)); err != nil {
 		t.Fatalf("expected ioctl success, got %v", err)
 	}
-	if err := ptyIoctl(0, 0, 0); err == nil {
+	if err := ptyIoctl(0, 0, nil); err == nil {
 		t.Fatalf("expected ioctl error")
 	}
 }
@@ -41,7 +41,7 @@ func TestOpenPTYWithOpenErrorLinux(t *testing.T) {
 	openFile := func(string, int, os.FileMode) (*os.File, error) {
 		return nil, errors.New("open failed")
 	}
-	_, _, err := openPTYWith(openFile, func(uintptr, uintptr, uintptr) error { return nil })
+	_, _, err := openPTYWith(openFile, func(uintptr, uintptr, unsafe.Pointer) error { return nil })
 	if err == nil || err.Error() != "open failed" {
 		t.Fatalf("expected open error, got %v", err)
 	}
@@ -51,7 +51,7 @@ func TestOpenPTYWithUnlockErrorLinux(t *testing.T) {
 	openFile := func(string, int, os.FileMode) (*os.File, error) {
 		return os.OpenFile(os.DevNull, os.O_RDWR, 0)
 	}
-	_, _, err := openPTYWith(openFile, func(fd uintptr, req uintptr, arg uintptr) error {
+	_, _, err := openPTYWith(openFile, func(fd uintptr, req uintptr, arg unsafe.Pointer) error {
 		if req == syscall.TIOCSPTLCK {
 			return errors.New("unlock failed")
 		}
@@ -66,7 +66,7 @@ func TestOpenPTYWithPTNErrorLinux(t *testing.T) {
 	openFile := func(string, int, os.FileMode) (*os.File, error) {
 		return os.OpenFile(os.DevNull, os.O_RDWR, 0)
 	}
-	_, _, err := openPTYWith(openFile, func(fd uintptr, req uintptr, arg uintptr) error {
+	_, _, err := openPTYWith(openFile, func(fd uintptr, req uintptr, arg unsafe.Pointer) error {
 		if req == syscall.TIOCGPTN {
 			return errors.New("ptn failed")
 		}
@@ -84,9 +84,9 @@ func TestOpenPTYWithSlaveErrorLinux(t *testing.T) {
 		}
 		return nil, errors.New("slave open failed")
 	}
-	ioctl := func(fd uintptr, req uintptr, arg uintptr) error {
+	ioctl := func(fd uintptr, req uintptr, arg unsafe.Pointer) error {
 		if req == syscall.TIOCGPTN {
-			*(*uint32)(unsafe.Pointer(arg)) = 1234
+			*(*uint32)(arg) = 1234
 		}
 		return nil
 	}
@@ -100,9 +100,9 @@ func TestOpenPTYWithSuccessLinux(t *testing.T) {
 	openFile := func(name string, flag int, perm os.FileMode) (*os.File, error) {
 		return os.OpenFile(os.DevNull, os.O_RDWR, 0)
 	}
-	ioctl := func(fd uintptr, req uintptr, arg uintptr) error {
+	ioctl := func(fd uintptr, req uintptr, arg unsafe.Pointer) error {
 		if req == syscall.TIOCGPTN {
-			*(*uint32)(unsafe.Pointer(arg)) = 0
+			*(*uint32)(arg) = 0
 		}
 		return nil
 	}
@@ -116,3 +116,62 @@ func TestOpenPTYWithSuccessLinux(t *testing.T) {
 		t.Fatalf("expected dev null files, got %q %q", master.Name(), slave.Name())
 	}
 }
+
+func TestPtySetWinsizeLinux(t *testing.T) {
+	master, slave, err := openPTY()
+	if err != nil {
+		t.Fatalf("openPTY: %v", err)
+	}
+	defer master.Close()
+	defer slave.Close()
+
+	if err := ptySetWinsize(master, 40, 120); err != nil {
+		t.Fatalf("ptySetWinsize: %v", err)
+	}
+
+	var ws winsize
+	if err := ptyIoctl(slave.Fd(), syscall.TIOCGWINSZ, unsafe.Pointer(&ws)); err != nil {
+		t.Fatalf("TIOCGWINSZ: %v", err)
+	}
+	if ws.row != 40 || ws.col != 120 {
+		t.Fatalf("expected 40x120, got %dx%d", ws.row, ws.col)
+	}
+}
+
+func TestPtyMakeRawRestoreLinux(t *testing.T) {
+	master, slave, err := openPTY()
+	if err != nil {
+		t.Fatalf("openPTY: %v", err)
+	}
+	defer master.Close()
+	defer slave.Close()
+
+	var before syscall.Termios
+	if err := ptyIoctl(slave.Fd(), tcgets, unsafe.Pointer(&before)); err != nil {
+		t.Fatalf("tcgets: %v", err)
+	}
+
+	restore, err := ptyMakeRaw(slave)
+	if err != nil {
+		t.Fatalf("ptyMakeRaw: %v", err)
+	}
+
+	var raw syscall.Termios
+	if err := ptyIoctl(slave.Fd(), tcgets, unsafe.Pointer(&raw)); err != nil {
+		t.Fatalf("tcgets after raw: %v", err)
+	}
+	if raw.Lflag&lEcho != 0 || raw.Lflag&lIcanon != 0 {
+		t.Fatalf("expected echo and canonical mode cleared, got lflag %#o", raw.Lflag)
+	}
+
+	if err := restore(); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	var after syscall.Termios
+	if err := ptyIoctl(slave.Fd(), tcgets, unsafe.Pointer(&after)); err != nil {
+		t.Fatalf("tcgets after restore: %v", err)
+	}
+	if after.Lflag != before.Lflag {
+		t.Fatalf("expected lflag restored to %#o, got %#o", before.Lflag, after.Lflag)
+	}
+}
@@ -0,0 +1,133 @@
+package execx
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestWithPTYAttachesIsATTY(t *testing.T) {
+	if err := ptyCheck(); err != nil {
+		t.Skipf("pty not supported: %v", err)
+	}
+	res, err := helperCommand("isatty").WithPTY().Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res.Stdout != "true" {
+		t.Fatalf("expected child to see a tty, got stdout %q", res.Stdout)
+	}
+}
+
+func TestWithPTYTeesMasterToOnStdout(t *testing.T) {
+	if err := ptyCheck(); err != nil {
+		t.Skipf("pty not supported: %v", err)
+	}
+	var lines []string
+	res, err := helperCommand("echo", "hi").
+		WithPTY().
+		OnStdout(func(line string) { lines = append(lines, line) }).
+		Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(lines) != 1 || lines[0] != "hi" {
+		t.Fatalf("expected OnStdout to see [hi], got %v", lines)
+	}
+	if res.Stdout != "hi" {
+		t.Fatalf("expected stdout %q, got %q", "hi", res.Stdout)
+	}
+}
+
+func TestWithPTYSizeStartsWithMaster(t *testing.T) {
+	if err := ptyCheck(); err != nil {
+		t.Skipf("pty not supported: %v", err)
+	}
+	proc := helperCommand("sleep", "20").WithPTY(WithPTYSize(40, 120)).Start()
+	master := proc.PTYMaster()
+	if master == nil {
+		t.Fatalf("expected a non-nil pty master after Start")
+	}
+	_, _ = proc.Wait()
+}
+
+func TestPTYMasterNilWithoutPTY(t *testing.T) {
+	proc := helperCommand("echo", "hi").Start()
+	master := proc.PTYMaster()
+	_, _ = proc.Wait()
+	if master != nil {
+		t.Fatalf("expected no pty master without WithPTY")
+	}
+}
+
+func TestWithPTYUnsupportedReportsError(t *testing.T) {
+	if err := ptyCheck(); err == nil {
+		t.Skip("pty is supported on this platform")
+	}
+	_, err := helperCommand("echo", "hi").WithPTY().Run()
+	if err == nil {
+		t.Fatalf("expected an error on a platform without pty support")
+	}
+}
+
+func TestOpenPTYFuncInjection(t *testing.T) {
+	if err := ptyCheck(); err != nil {
+		t.Skipf("pty not supported: %v", err)
+	}
+	orig := openPTYFunc
+	defer func() { openPTYFunc = orig }()
+
+	wantErr := ErrNoPTY
+	openPTYFunc = func() (*os.File, *os.File, error) { return nil, nil, wantErr }
+
+	_, err := helperCommand("echo", "hi").WithPTY().Run()
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected underlying openPTYFunc error to surface, got %v", err)
+	}
+}
+
+func TestPTYSizeWithoutPTYIsNoop(t *testing.T) {
+	res, err := helperCommand("echo", "hi").PTYSize(24, 80).Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res.Stdout != "hi" {
+		t.Fatalf("expected stdout %q, got %q", "hi", res.Stdout)
+	}
+}
+
+func TestPTYStdinWithoutPTYIsNoop(t *testing.T) {
+	res, err := helperCommand("echo", "hi").PTYStdin(nil).Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res.Stdout != "hi" {
+		t.Fatalf("expected stdout %q, got %q", "hi", res.Stdout)
+	}
+}
+
+func TestPTYRawModeWithoutPTYIsNoop(t *testing.T) {
+	res, err := helperCommand("echo", "hi").PTYRawMode().Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res.Stdout != "hi" {
+		t.Fatalf("expected stdout %q, got %q", "hi", res.Stdout)
+	}
+}
+
+func TestResizeNotStarted(t *testing.T) {
+	proc := &Process{}
+	if err := proc.Resize(24, 80); err == nil {
+		t.Fatalf("expected error for unstarted process")
+	}
+}
+
+func TestResizeWithoutPTY(t *testing.T) {
+	proc := helperCommand("echo", "hi").Start()
+	err := proc.Resize(24, 80)
+	_, _ = proc.Wait()
+	if err != ErrNoPTY {
+		t.Fatalf("expected ErrNoPTY, got %v", err)
+	}
+}
@@ -5,6 +5,7 @@ package execx
 import (
 	"errors"
 	"os"
+	"os/exec"
 )
 
 func ptyCheck() error {
@@ -14,3 +15,15 @@ func ptyCheck() error {
 func openPTY() (*os.File, *os.File, error) {
 	return nil, nil, ptyCheck()
 }
+
+func ptySetWinsize(_ *os.File, _, _ uint16) error {
+	return ptyCheck()
+}
+
+func ptyMakeRaw(_ *os.File) (func() error, error) {
+	return nil, ptyCheck()
+}
+
+// attachPTYSession is never reached on this platform: openPTY always
+// fails first, so WithPTY never starts a process here.
+func attachPTYSession(_ *exec.Cmd, _ *os.File) {}
@@ -0,0 +1,374 @@
+package execx
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/goforj/execx/internal/wire"
+)
+
+// Dialer opens connections to a remote execx agent. Implementations must
+// be safe for concurrent use, since a single pipeline may dial several
+// stages against the same dialer at once.
+type Dialer interface {
+	Dial() (net.Conn, error)
+}
+
+type netDialer struct {
+	network string
+	address string
+}
+
+func (d *netDialer) Dial() (net.Conn, error) {
+	return net.Dial(d.network, d.address)
+}
+
+// Remote builds a Dialer for an execx agent listening at endpoint.
+// Endpoint may be "unix:///path/to.sock", "tcp://host:port", or a bare
+// "host:port" (assumed tcp).
+// @group Remote
+//
+// Example: remote
+//
+//	dialer := execx.Remote("tcp://127.0.0.1:9123")
+//	fmt.Println(dialer != nil)
+//	// #bool true
+func Remote(endpoint string) Dialer {
+	network := "tcp"
+	address := endpoint
+	if rest, ok := strings.CutPrefix(endpoint, "unix://"); ok {
+		network, address = "unix", rest
+	} else if rest, ok := strings.CutPrefix(endpoint, "tcp://"); ok {
+		address = rest
+	}
+	return &netDialer{network: network, address: address}
+}
+
+// CommandOn constructs a new command that executes against dialer instead
+// of the local host, while supporting the same builder API as Command.
+// @group Remote
+//
+// Example: command on
+//
+//	dialer := execx.Remote("tcp://127.0.0.1:9123")
+//	cmd := execx.CommandOn(dialer, "go", "env", "GOOS")
+//	fmt.Println(strings.Join(cmd.Args(), " "))
+//	// #string go env GOOS
+func CommandOn(dialer Dialer, name string, args ...string) *Cmd {
+	cmd := Command(name, args...)
+	cmd.dialer = dialer
+	return cmd
+}
+
+func (c *Cmd) remoteChainStages() []*Cmd {
+	root := c.rootCmd()
+	stages := []*Cmd{}
+	for cur := root; cur != nil; cur = cur.next {
+		stages = append(stages, cur)
+	}
+	return stages
+}
+
+func (c *Cmd) hasRemoteStage() bool {
+	for _, stage := range c.remoteChainStages() {
+		if stage.dialer != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteSegments groups consecutive stages that share the same dialer (nil
+// included, for consecutive local stages) so each group can be executed as
+// a single hop, splicing the pipeline's stdout/stdin across hops.
+func remoteSegments(stages []*Cmd) [][]*Cmd {
+	var segments [][]*Cmd
+	for _, stage := range stages {
+		if len(segments) == 0 || segments[len(segments)-1][0].dialer != stage.dialer {
+			segments = append(segments, []*Cmd{stage})
+			continue
+		}
+		last := len(segments) - 1
+		segments[last] = append(segments[last], stage)
+	}
+	return segments
+}
+
+// runRemoteChainAll executes every segment of a (possibly multi-host)
+// pipeline in order, feeding each segment's final stdout into the next
+// segment's stdin, and returns every stage's Result in pipeline order.
+func (c *Cmd) runRemoteChainAll() []Result {
+	root := c.rootCmd()
+	segments := remoteSegments(c.remoteChainStages())
+
+	var stdin io.Reader = root.stdin
+	var all []Result
+	for _, seg := range segments {
+		var results []Result
+		var err error
+		if seg[0].dialer != nil {
+			results, err = runRemoteSegment(seg, stdin)
+		} else {
+			results, err = runLocalSegment(seg, stdin)
+		}
+		if err != nil {
+			all = append(all, results...)
+			break
+		}
+		all = append(all, results...)
+		last := results[len(results)-1]
+		if root.pipeMode == pipeStrict && (last.ExitCode != 0 || last.Err != nil) {
+			break
+		}
+		stdin = strings.NewReader(last.Stdout)
+	}
+	return all
+}
+
+func (c *Cmd) runRemoteChain() (Result, string) {
+	root := c.rootCmd()
+	results := c.runRemoteChainAll()
+	if len(results) == 0 {
+		err := errors.New("remote pipeline produced no stages")
+		return Result{ExitCode: -1, Err: err}, ""
+	}
+
+	primaryIndex := len(results) - 1
+	if root.pipeMode == pipeStrict {
+		for i, res := range results {
+			if res.ExitCode != 0 || res.Err != nil {
+				primaryIndex = i
+				break
+			}
+		}
+	}
+	primary := results[primaryIndex]
+	if root.pipeMode == pipeBestEffort && primary.Err == nil {
+		for _, res := range results {
+			if res.Err != nil {
+				primary.Err = res.Err
+				break
+			}
+		}
+	}
+	return primary, primary.Stdout + primary.Stderr
+}
+
+func (c *Cmd) startRemoteChain() *Process {
+	proc := &Process{mode: c.rootCmd().pipeMode, done: make(chan struct{})}
+
+	stages := c.remoteChainStages()
+	segments := remoteSegments(stages)
+	if len(segments) == 1 && segments[0][0].dialer != nil {
+		sess, err := dialRemoteSegment(segments[0])
+		if err != nil {
+			proc.finish(Result{ExitCode: -1, Err: err})
+			return proc
+		}
+		proc.remote = sess
+		go func() {
+			result, _ := sess.wait()
+			proc.finish(result)
+		}()
+		return proc
+	}
+
+	// Mixed-host pipelines don't have a single long-lived connection to
+	// attach Process control to, so run them to completion in the
+	// background and surface the final Result through Wait.
+	go func() {
+		result, _ := c.runRemoteChain()
+		proc.finish(result)
+	}()
+	return proc
+}
+
+func runRemoteSegment(seg []*Cmd, stdin io.Reader) ([]Result, error) {
+	sess, err := dialRemoteSegment(seg)
+	if err != nil {
+		return []Result{{ExitCode: -1, Err: err}}, err
+	}
+	defer sess.conn.Close()
+
+	var stdinBytes []byte
+	if stdin != nil {
+		stdinBytes, err = io.ReadAll(stdin)
+		if err != nil {
+			return []Result{{ExitCode: -1, Err: err}}, err
+		}
+	}
+
+	req := wire.Request{PipeMode: int(seg[0].rootCmd().pipeMode), Stdin: stdinBytes}
+	for _, s := range seg {
+		req.Stages = append(req.Stages, wire.StageSpec{
+			Name: s.name,
+			Args: append([]string{}, s.args...),
+			Env:  buildEnv(s.envMode, s.env, s.envAllow, s.envDeny),
+			Dir:  s.dir,
+		})
+	}
+	if err := wire.WriteMessage(sess.conn, wire.Envelope{Request: &req}); err != nil {
+		return []Result{{ExitCode: -1, Err: err}}, err
+	}
+
+	resp, err := sess.readResponse(seg)
+	if err != nil {
+		return []Result{{ExitCode: -1, Err: err}}, err
+	}
+	results := make([]Result, len(resp.Results))
+	for i, sr := range resp.Results {
+		results[i] = Result{Stdout: sr.Stdout, Stderr: sr.Stderr, ExitCode: sr.ExitCode}
+		if sr.Err != "" {
+			results[i].Err = errors.New(sr.Err)
+		}
+	}
+	return results, nil
+}
+
+// runLocalSegment executes a run of local stages without going through the
+// cache/Run machinery (which would recurse back into remote dispatch), by
+// driving each stage's own execCmd/stdoutWriter/stderrWriter directly.
+func runLocalSegment(seg []*Cmd, stdin io.Reader) ([]Result, error) {
+	results := make([]Result, len(seg))
+	var carry io.Reader = stdin
+	for i, s := range seg {
+		cmd := s.execCmd()
+		if i == 0 {
+			if carry != nil {
+				cmd.Stdin = carry
+			} else {
+				cmd.Stdin = s.stdin
+			}
+		} else {
+			cmd.Stdin = carry
+		}
+
+		var stdoutBuf, stderrBuf bytes.Buffer
+		cmd.Stdout = s.stdoutWriter(&stdoutBuf, false, nil, i, cmd)
+		cmd.Stderr = s.stderrWriter(&stderrBuf, false, nil, i, cmd)
+
+		startErr := cmd.Start()
+		var waitErr error
+		if startErr == nil {
+			waitErr = cmd.Wait()
+		} else {
+			waitErr = startErr
+		}
+
+		res := Result{Stdout: stdoutBuf.String(), Stderr: stderrBuf.String(), ExitCode: -1}
+		if cmd.ProcessState != nil {
+			res.ExitCode = cmd.ProcessState.ExitCode()
+		}
+		if waitErr != nil && res.ExitCode == -1 {
+			res.Err = ErrExec{Err: waitErr, ExitCode: -1, Stderr: res.Stderr}
+		}
+		results[i] = res
+		carry = strings.NewReader(res.Stdout)
+	}
+	return results, nil
+}
+
+// remoteSession is a live connection to a remote agent backing an async
+// Process, letting Send/Terminate/GracefulShutdown forward signals.
+type remoteSession struct {
+	conn   net.Conn
+	stages []*Cmd
+}
+
+func dialRemoteSegment(seg []*Cmd) (*remoteSession, error) {
+	conn, err := seg[0].dialer.Dial()
+	if err != nil {
+		return nil, err
+	}
+	return &remoteSession{conn: conn, stages: seg}, nil
+}
+
+func (s *remoteSession) readResponse(seg []*Cmd) (wire.Response, error) {
+	for {
+		var envelope wire.Envelope
+		if err := wire.ReadMessage(s.conn, &envelope); err != nil {
+			return wire.Response{}, err
+		}
+		if envelope.Response != nil {
+			return *envelope.Response, nil
+		}
+		if envelope.Stream != nil {
+			deliverStream(seg, *envelope.Stream)
+		}
+	}
+}
+
+func deliverStream(seg []*Cmd, frame wire.StreamFrame) {
+	if frame.Stage < 0 || frame.Stage >= len(seg) {
+		return
+	}
+	stage := seg[frame.Stage]
+	switch frame.Kind {
+	case wire.StreamStdout:
+		if stage.onStdout != nil {
+			for _, line := range strings.Split(strings.TrimRight(string(frame.Data), "\n"), "\n") {
+				stage.onStdout(line)
+			}
+		}
+	case wire.StreamStderr:
+		if stage.onStderr != nil {
+			for _, line := range strings.Split(strings.TrimRight(string(frame.Data), "\n"), "\n") {
+				stage.onStderr(line)
+			}
+		}
+	}
+}
+
+func (s *remoteSession) signal(sig os.Signal) error {
+	number := 0
+	if n, ok := sig.(syscall.Signal); ok {
+		number = int(n)
+	}
+	return wire.WriteMessage(s.conn, wire.Envelope{Stream: &wire.StreamFrame{Kind: wire.StreamSignal, Signal: number}})
+}
+
+func (s *remoteSession) terminate() error {
+	return s.signal(syscall.SIGKILL)
+}
+
+func (s *remoteSession) wait() (Result, error) {
+	req := wire.Request{PipeMode: int(s.stages[0].rootCmd().pipeMode)}
+	for _, st := range s.stages {
+		req.Stages = append(req.Stages, wire.StageSpec{
+			Name: st.name,
+			Args: append([]string{}, st.args...),
+			Env:  buildEnv(st.envMode, st.env, st.envAllow, st.envDeny),
+			Dir:  st.dir,
+		})
+	}
+	if s.stages[0].stdin != nil {
+		data, err := io.ReadAll(s.stages[0].stdin)
+		if err != nil {
+			return Result{ExitCode: -1, Err: err}, err
+		}
+		req.Stdin = data
+	}
+	if err := wire.WriteMessage(s.conn, wire.Envelope{Request: &req}); err != nil {
+		return Result{ExitCode: -1, Err: err}, err
+	}
+
+	resp, err := s.readResponse(s.stages)
+	if err != nil {
+		return Result{ExitCode: -1, Err: err}, err
+	}
+	if len(resp.Results) == 0 {
+		err := errors.New("remote agent returned no results")
+		return Result{ExitCode: -1, Err: err}, err
+	}
+	last := resp.Results[len(resp.Results)-1]
+	result := Result{Stdout: last.Stdout, Stderr: last.Stderr, ExitCode: last.ExitCode}
+	if last.Err != "" {
+		result.Err = errors.New(last.Err)
+	}
+	return result, result.Err
+}
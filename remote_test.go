@@ -0,0 +1,78 @@
+package execx
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/goforj/execx/agent"
+)
+
+func startTestAgent(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	go agent.ServeListener(ln)
+	t.Cleanup(func() { ln.Close() })
+	return ln.Addr().String()
+}
+
+func TestCommandOnRun(t *testing.T) {
+	addr := startTestAgent(t)
+	dialer := Remote("tcp://" + addr)
+
+	res, err := CommandOn(dialer, "printf", "hi").Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res.Stdout != "hi" {
+		t.Fatalf("unexpected stdout: %q", res.Stdout)
+	}
+}
+
+func TestCommandOnPipe(t *testing.T) {
+	addr := startTestAgent(t)
+	dialer := Remote("tcp://" + addr)
+
+	out, err := CommandOn(dialer, "printf", "go").
+		Pipe("tr", "a-z", "A-Z").
+		OutputTrimmed()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if out != "GO" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestPipeOnSplicesAcrossHosts(t *testing.T) {
+	addr := startTestAgent(t)
+	dialer := Remote("tcp://" + addr)
+
+	out, err := Command("printf", "go").
+		PipeOn(dialer, "tr", "a-z", "A-Z").
+		OutputTrimmed()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if out != "GO" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestCommandOnStartAndTerminate(t *testing.T) {
+	addr := startTestAgent(t)
+	dialer := Remote("tcp://" + addr)
+
+	proc := CommandOn(dialer, "sleep", "2").Start()
+	if err := proc.Terminate(); err != nil {
+		t.Fatalf("terminate: %v", err)
+	}
+	select {
+	case <-proc.done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("process did not finish after terminate")
+	}
+}
@@ -0,0 +1,48 @@
+//go:build unix
+
+package execx
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// ResolveExitCode derives a POSIX-style exit code from an error returned
+// by running a command: 127/126 for a startup failure classified as
+// ClassNotFound/ClassPermission, 128+signum for death by signal, and the
+// child's own exit code otherwise, the way a shell reports $? for the
+// same failure.
+// @group Results
+//
+// Example: resolve exit code
+//
+//	_, err := execx.Command("execx-no-such-binary").Run()
+//	fmt.Println(execx.ResolveExitCode(err))
+//	// #int 127
+func ResolveExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var execErr *exec.Error
+	if errors.As(err, &execErr) {
+		return startupExitCode(classifyStartErr(execErr))
+	}
+	var pathErr *os.PathError
+	if errors.As(err, &pathErr) {
+		return startupExitCode(classifyStartErr(pathErr))
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if waitStatus, ok := exitErr.ProcessState.Sys().(syscall.WaitStatus); ok && waitStatus.Signaled() {
+			return 128 + int(waitStatus.Signal())
+		}
+		return exitErr.ExitCode()
+	}
+	var ee ErrExec
+	if errors.As(err, &ee) {
+		return ee.ExitCode
+	}
+	return -1
+}
@@ -0,0 +1,49 @@
+//go:build windows
+
+package execx
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// ResolveExitCode derives an exit code from an error returned by running
+// a command: 127/126 for a startup failure classified as
+// ClassNotFound/ClassPermission, and the child's own exit code otherwise.
+// Windows has no signal semantics, so unlike the Unix build this never
+// synthesizes a 128+signum code; ProcessState.Sys().(syscall.WaitStatus)
+// only exposes ExitStatus().
+// @group Results
+//
+// Example: resolve exit code
+//
+//	_, err := execx.Command("execx-no-such-binary").Run()
+//	fmt.Println(execx.ResolveExitCode(err))
+//	// #int 127
+func ResolveExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var execErr *exec.Error
+	if errors.As(err, &execErr) {
+		return startupExitCode(classifyStartErr(execErr))
+	}
+	var pathErr *os.PathError
+	if errors.As(err, &pathErr) {
+		return startupExitCode(classifyStartErr(pathErr))
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if waitStatus, ok := exitErr.ProcessState.Sys().(syscall.WaitStatus); ok {
+			return waitStatus.ExitStatus()
+		}
+		return exitErr.ExitCode()
+	}
+	var ee ErrExec
+	if errors.As(err, &ee) {
+		return ee.ExitCode
+	}
+	return -1
+}
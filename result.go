@@ -12,8 +12,27 @@ type Result struct {
 	ExitCode int
 	Err      error
 	Duration time.Duration
+	Events   []Event
 
-	signal os.Signal
+	// LimitExceeded names the Limits field that most likely caused the
+	// process to be terminated, e.g. "memory" or "cpu_time". Empty when
+	// no limit was configured or none appears to have fired.
+	LimitExceeded string
+
+	// Rusage reports CPU time, memory, and I/O usage collected from the
+	// finished process. Zero when the process never started.
+	Rusage Rusage
+
+	// ElapsedWall is the wall-clock time between this process spawning
+	// and Wait returning for it, as opposed to Duration, which is
+	// measured from the stage being set up, before the process exists.
+	ElapsedWall time.Duration
+
+	signal       os.Signal
+	stopped      bool
+	stopSignal   os.Signal
+	coreDumped   bool
+	processState *os.ProcessState
 }
 
 // OK reports whether the command exited cleanly without errors.
@@ -21,7 +40,7 @@ type Result struct {
 //
 // Example: ok
 //
-//	res := execx.Command("go", "env", "GOOS").Run()
+//	res, _ := execx.Command("go", "env", "GOOS").Run()
 //	fmt.Println(res.OK())
 //	// #bool true
 func (r Result) OK() bool {
@@ -33,7 +52,7 @@ func (r Result) OK() bool {
 //
 // Example: exit code
 //
-//	res := execx.Command("go", "env", "GOOS").Run()
+//	res, _ := execx.Command("go", "env", "GOOS").Run()
 //	fmt.Println(res.IsExitCode(0))
 //	// #bool true
 func (r Result) IsExitCode(code int) bool {
@@ -45,9 +64,171 @@ func (r Result) IsExitCode(code int) bool {
 //
 // Example: signal
 //
-//	res := execx.Command("go", "env", "GOOS").Run()
+//	res, _ := execx.Command("go", "env", "GOOS").Run()
 //	fmt.Println(res.IsSignal(os.Interrupt))
 //	// #bool false
 func (r Result) IsSignal(sig os.Signal) bool {
 	return r.signal == sig
 }
+
+// IsSignaled reports whether the command terminated due to any signal,
+// equivalent to Classify() == ClassSignaled. On Windows, which has no
+// signal semantics, this is always false.
+// @group Results
+//
+// Example: signaled
+//
+//	res, _ := execx.Command("go", "env", "GOOS").Run()
+//	fmt.Println(res.IsSignaled())
+//	// #bool false
+func (r Result) IsSignaled() bool {
+	return r.signal != nil
+}
+
+// IsKilled reports whether the command was terminated by SIGKILL, the
+// signal WithLimits and a cancelled context's forceful cleanup use when
+// a process doesn't respond to a gentler signal. On Windows this is
+// always false.
+// @group Results
+//
+// Example: killed
+//
+//	res, _ := execx.Command("go", "env", "GOOS").Run()
+//	fmt.Println(res.IsKilled())
+//	// #bool false
+func (r Result) IsKilled() bool {
+	return r.IsSignal(os.Kill)
+}
+
+// IsCanceled reports whether the result represents a cancelled context,
+// equivalent to Classify() == ClassCancelled. It does not match a
+// ClassTimeout, which has its own distinct class.
+// @group Results
+//
+// Example: canceled
+//
+//	res, _ := execx.Command("go", "env", "GOOS").Run()
+//	fmt.Println(res.IsCanceled())
+//	// #bool false
+func (r Result) IsCanceled() bool {
+	return r.Classify() == ClassCancelled
+}
+
+// IsStartError reports whether the command never ran at all, equivalent
+// to Classify() being one of ClassStartupFailure, ClassNotFound, or
+// ClassPermission.
+// @group Results
+//
+// Example: start error
+//
+//	res, _ := execx.Command("go", "env", "GOOS").Run()
+//	fmt.Println(res.IsStartError())
+//	// #bool false
+func (r Result) IsStartError() bool {
+	switch r.Classify() {
+	case ClassStartupFailure, ClassNotFound, ClassPermission:
+		return true
+	default:
+		return false
+	}
+}
+
+// Signal returns the signal that terminated the command, or nil if it
+// exited normally, failed to start, or never ran.
+// @group Results
+//
+// Example: result signal
+//
+//	res, _ := execx.Command("go", "env", "GOOS").Run()
+//	fmt.Println(res.Signal())
+//	// #nil
+func (r Result) Signal() os.Signal {
+	return r.signal
+}
+
+// SignalName returns the terminating signal's String(), or "" if the
+// command wasn't terminated by a signal.
+// @group Results
+//
+// Example: signal name
+//
+//	res, _ := execx.Command("go", "env", "GOOS").Run()
+//	fmt.Println(res.SignalName())
+//	// #string
+func (r Result) SignalName() string {
+	if r.signal == nil {
+		return ""
+	}
+	return r.signal.String()
+}
+
+// Stopped reports whether the process was stopped (as by SIGSTOP) rather
+// than exited. Run/Start/Wait never request WUNTRACED notifications, so
+// in practice this is always false for them; it exists for completeness
+// alongside StopSignal. Pause/Resume track deliberate suspension
+// separately via Process.Paused, which doesn't go through Wait at all.
+// @group Results
+//
+// Example: stopped
+//
+//	res, _ := execx.Command("go", "env", "GOOS").Run()
+//	fmt.Println(res.Stopped())
+//	// #bool false
+func (r Result) Stopped() bool {
+	return r.stopped
+}
+
+// StopSignal returns the signal that stopped the process, or nil if
+// Stopped is false.
+// @group Results
+//
+// Example: stop signal
+//
+//	res, _ := execx.Command("go", "env", "GOOS").Run()
+//	fmt.Println(res.StopSignal())
+//	// #nil
+func (r Result) StopSignal() os.Signal {
+	return r.stopSignal
+}
+
+// CoreDumped reports whether the terminating signal produced a core
+// dump. Always false on Windows, which has no equivalent.
+// @group Results
+//
+// Example: core dumped
+//
+//	res, _ := execx.Command("go", "env", "GOOS").Run()
+//	fmt.Println(res.CoreDumped())
+//	// #bool false
+func (r Result) CoreDumped() bool {
+	return r.coreDumped
+}
+
+// TimedOut reports whether the result represents an expired context
+// deadline, equivalent to Classify() == ClassTimeout.
+// @group Results
+//
+// Example: timed out
+//
+//	res, _ := execx.Command("go", "env", "GOOS").Run()
+//	fmt.Println(res.TimedOut())
+//	// #bool false
+func (r Result) TimedOut() bool {
+	return r.Classify() == ClassTimeout
+}
+
+// OOMKilled reports whether this result looks like a memory-limit kill:
+// the process was terminated by SIGKILL and LimitExceeded names
+// "memory". This recognizes the ulimit-based memory cap WithLimits
+// itself enforces; it has no visibility into an external cgroup's OOM
+// killer.
+// @group Results
+//
+// Example: oom killed
+//
+//	res, _ := execx.Command("go", "env", "GOOS").Run()
+//	fmt.Println(res.OOMKilled())
+//	// #bool false
+func (r Result) OOMKilled() bool {
+	return r.IsKilled() && r.LimitExceeded == "memory"
+}
@@ -0,0 +1,113 @@
+package execx
+
+import (
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestResultSignalAndSignalName(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("signals not supported on windows")
+	}
+	res, _ := helperCommand("signal").Run()
+	if res.Signal() != syscall.SIGTERM {
+		t.Fatalf("expected SIGTERM, got %v", res.Signal())
+	}
+	if res.SignalName() != syscall.SIGTERM.String() {
+		t.Fatalf("expected %q, got %q", syscall.SIGTERM.String(), res.SignalName())
+	}
+
+	res, err := helperCommand("echo", "hi").Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res.Signal() != nil {
+		t.Fatalf("expected no signal, got %v", res.Signal())
+	}
+	if res.SignalName() != "" {
+		t.Fatalf("expected empty signal name, got %q", res.SignalName())
+	}
+}
+
+func TestResultStoppedIsAlwaysFalse(t *testing.T) {
+	res, err := helperCommand("echo", "hi").Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res.Stopped() {
+		t.Fatalf("expected Stopped to be false, Wait never requests WUNTRACED")
+	}
+	if res.StopSignal() != nil {
+		t.Fatalf("expected no stop signal, got %v", res.StopSignal())
+	}
+}
+
+func TestResultCoreDumped(t *testing.T) {
+	res, err := helperCommand("echo", "hi").Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res.CoreDumped() {
+		t.Fatalf("expected no core dump for a clean exit")
+	}
+}
+
+func TestResultTimedOut(t *testing.T) {
+	res, _ := helperCommand("sleep", "200").WithTimeout(50 * time.Millisecond).Run()
+	if !res.TimedOut() {
+		t.Fatalf("expected TimedOut, got classify %v", res.Classify())
+	}
+	if res.IsCanceled() {
+		t.Fatalf("expected a timeout not to also classify as canceled")
+	}
+}
+
+func TestResultOOMKilled(t *testing.T) {
+	res, err := helperCommand("echo", "hi").Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res.OOMKilled() {
+		t.Fatalf("expected OOMKilled false for a clean exit")
+	}
+}
+
+func TestResultIsTransient(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("signals not supported on windows")
+	}
+	res, _ := helperCommand("signal").Run()
+	if !res.IsTransient() {
+		t.Fatalf("expected SIGTERM to be transient")
+	}
+
+	res, err := helperCommand("echo", "hi").Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res.IsTransient() {
+		t.Fatalf("expected a clean exit not to be transient")
+	}
+}
+
+func TestResultIsCrash(t *testing.T) {
+	res, err := helperCommand("echo", "hi").Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res.IsCrash() {
+		t.Fatalf("expected a clean exit not to look like a crash")
+	}
+}
+
+func TestResultSysRusage(t *testing.T) {
+	res, err := helperCommand("echo", "hi").Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res.SysRusage() == nil {
+		t.Fatalf("expected a non-nil SysRusage after the process ran")
+	}
+}
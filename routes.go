@@ -0,0 +1,343 @@
+package execx
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// lineRoute is a single OnMatch/OnMatchStderr registration. The pattern is
+// compiled lazily by compileRoutes rather than at registration time, so a
+// bad pattern surfaces through Result.Err the way every other
+// misconfiguration in this package does, instead of panicking inside the
+// builder call.
+type lineRoute struct {
+	pattern string
+	re      *regexp.Regexp
+	fn      func(line string, groups []string)
+	kind    EventKind
+}
+
+// OnMatch runs fn for every stdout line matching pattern, passing the line
+// and its regexp submatches. Multiple routes may match the same line.
+// @group Streaming
+//
+// Example: on match
+//
+//	var hits []string
+//	_, _ = execx.Command("go", "env", "GOOS").
+//		OnMatch(`^\w+$`, func(line string, groups []string) { hits = append(hits, line) }).
+//		Run()
+//	fmt.Println(len(hits) > 0)
+//	// #bool true
+func (c *Cmd) OnMatch(pattern string, fn func(line string, groups []string)) *Cmd {
+	c.routes = append(c.routes, lineRoute{pattern: pattern, fn: fn, kind: EventStdout})
+	return c
+}
+
+// OnMatchStderr runs fn for every stderr line matching pattern, passing
+// the line and its regexp submatches.
+// @group Streaming
+//
+// Example: on match stderr
+//
+//	var hits []string
+//	_, _ = execx.Command("go", "env", "-badflag").
+//		OnMatchStderr(`flag provided`, func(line string, groups []string) { hits = append(hits, line) }).
+//		Run()
+//	fmt.Println(len(hits) > 0)
+//	// #bool true
+func (c *Cmd) OnMatchStderr(pattern string, fn func(line string, groups []string)) *Cmd {
+	c.routes = append(c.routes, lineRoute{pattern: pattern, fn: fn, kind: EventStderr})
+	return c
+}
+
+// compileRoutes compiles every pending OnMatch/OnMatchStderr pattern that
+// hasn't been compiled yet, returning the first error encountered. Called
+// from newPipeline so a bad pattern becomes a stage setupErr (surfaced
+// through Result.Err like ErrLimitsUnsupported/ErrSandboxUnsupported)
+// rather than a panic at OnMatch/OnMatchStderr call time.
+func (c *Cmd) compileRoutes() error {
+	for i := range c.routes {
+		if c.routes[i].re != nil {
+			continue
+		}
+		re, err := regexp.Compile(c.routes[i].pattern)
+		if err != nil {
+			return fmt.Errorf("execx: OnMatch: %w", err)
+		}
+		c.routes[i].re = re
+	}
+	return nil
+}
+
+// routingWriter splits writes into lines and dispatches every line to the
+// OnMatch/OnMatchStderr routes registered for its kind.
+type routingWriter struct {
+	routes []lineRoute
+	kind   EventKind
+	buf    bytes.Buffer
+}
+
+func (w *routingWriter) Write(p []byte) (int, error) {
+	for _, b := range p {
+		if b != '\n' {
+			_ = w.buf.WriteByte(b)
+			continue
+		}
+		line := strings.TrimSuffix(w.buf.String(), "\r")
+		w.buf.Reset()
+		for _, route := range w.routes {
+			if route.kind != w.kind {
+				continue
+			}
+			if groups := route.re.FindStringSubmatch(line); groups != nil {
+				route.fn(line, groups)
+			}
+		}
+	}
+	return len(p), nil
+}
+
+// MatchMode controls how many of a Router's routes run for a single line.
+type MatchMode int
+
+const (
+	// MatchFirst runs only the first route (in registration order) whose
+	// pattern matches a line, then stops. This is the default.
+	MatchFirst MatchMode = iota
+	// MatchAll runs every route whose pattern matches a line.
+	MatchAll
+)
+
+// RouterOption configures a Router.
+type RouterOption func(*Router)
+
+// WithMatchMode sets whether a Router stops at the first matching route
+// (the default, MatchFirst) or runs every route that matches (MatchAll).
+// @group Streaming
+//
+// Example: match mode
+//
+//	r := execx.NewRouter(execx.WithMatchMode(execx.MatchAll))
+//	fmt.Println(r != nil)
+//	// #bool true
+func WithMatchMode(mode MatchMode) RouterOption {
+	return func(r *Router) { r.mode = mode }
+}
+
+// Router dispatches the lines a command writes to stdout/stderr to
+// pattern-matched handlers, so callers don't have to reimplement matching
+// inside an OnStdout/OnStderr closure. Attach it to a Cmd with WithRouter;
+// each pipeline stage can carry its own Router the same way each can carry
+// its own OnStdout callback.
+type Router struct {
+	mode       MatchMode
+	routes     []*Route
+	catchall   func(line string, stream EventKind)
+	compileErr error
+}
+
+// Route is a single pattern registered on a Router, configured by chaining
+// On (a per-line handler) or Block (a sentinel-delimited multi-line
+// handler).
+type Route struct {
+	match   func(string) bool
+	onLine  func(line string, stream EventKind)
+	onBlock func(lines []string, stream EventKind)
+}
+
+// NewRouter creates an empty Router. Add routes with Route and an optional
+// Catchall, then attach it to a Cmd with WithRouter.
+// @group Streaming
+//
+// Example: new router
+//
+//	r := execx.NewRouter()
+//	fmt.Println(r != nil)
+//	// #bool true
+func NewRouter(opts ...RouterOption) *Router {
+	r := &Router{}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Route registers pattern on the router and returns a *Route to attach a
+// handler to with On or Block. pattern is a literal line prefix (string),
+// a compiled *regexp.Regexp, or a predicate (func(string) bool); any other
+// type is recorded as a compile error and surfaced through Result.Err when
+// the command starts, the same way a bad OnMatch pattern is, rather than
+// panicking here.
+// @group Streaming
+//
+// Example: route
+//
+//	var hits []string
+//	r := execx.NewRouter()
+//	r.Route("GOOS").On(func(line string, stream execx.EventKind) { hits = append(hits, line) })
+//	_, _ = execx.Command("go", "env", "GOOS").WithRouter(r).Run()
+//	fmt.Println(len(hits) > 0)
+//	// #bool true
+func (r *Router) Route(pattern any) *Route {
+	match, err := compileRouteMatch(pattern)
+	if err != nil && r.compileErr == nil {
+		r.compileErr = err
+	}
+	route := &Route{match: match}
+	r.routes = append(r.routes, route)
+	return route
+}
+
+// Catchall registers fn to run for every line offered to the router that
+// matched none of its routes. A later call replaces an earlier one.
+// @group Streaming
+//
+// Example: catchall
+//
+//	var misses []string
+//	r := execx.NewRouter()
+//	r.Route("nomatch").On(func(string, execx.EventKind) {})
+//	r.Catchall(func(line string, stream execx.EventKind) { misses = append(misses, line) })
+//	_, _ = execx.Command("go", "env", "GOOS").WithRouter(r).Run()
+//	fmt.Println(len(misses) > 0)
+//	// #bool true
+func (r *Router) Catchall(fn func(line string, stream EventKind)) *Router {
+	r.catchall = fn
+	return r
+}
+
+func compileRouteMatch(pattern any) (func(string) bool, error) {
+	switch p := pattern.(type) {
+	case string:
+		return func(line string) bool { return strings.HasPrefix(line, p) }, nil
+	case *regexp.Regexp:
+		return p.MatchString, nil
+	case func(string) bool:
+		return p, nil
+	default:
+		return func(string) bool { return false },
+			fmt.Errorf("execx: Route: unsupported pattern type %T", pattern)
+	}
+}
+
+// On attaches fn as this route's per-line handler, run once for every line
+// matching the route's pattern.
+// @group Streaming
+//
+// Example: on
+//
+//	var hits []string
+//	r := execx.NewRouter()
+//	r.Route("GOOS").On(func(line string, stream execx.EventKind) { hits = append(hits, line) })
+//	_, _ = execx.Command("go", "env", "GOOS").WithRouter(r).Run()
+//	fmt.Println(len(hits) > 0)
+//	// #bool true
+func (rt *Route) On(fn func(line string, stream EventKind)) *Route {
+	rt.onLine = fn
+	return rt
+}
+
+// Block turns this route into a multi-line collector: the first line that
+// matches its pattern opens a block, every following line is buffered
+// (without being offered to any other route) until a line matching the
+// same pattern closes it, and fn then runs once with the buffered interior
+// lines (the opening and closing delimiter lines themselves excluded).
+// This is the sentinel-delimited capture for fenced multi-line output,
+// e.g. a repeated "---" marker wrapping a stack trace.
+// @group Streaming
+//
+// Example: block
+//
+//	var blocks [][]string
+//	r := execx.NewRouter()
+//	r.Route("---").Block(func(lines []string, stream execx.EventKind) { blocks = append(blocks, lines) })
+//	_, _ = execx.Command("printf", "---\na\nb\n---\n").WithRouter(r).Run()
+//	fmt.Println(len(blocks) == 1 && len(blocks[0]) == 2)
+//	// #bool true
+func (rt *Route) Block(fn func(lines []string, stream EventKind)) *Route {
+	rt.onBlock = fn
+	return rt
+}
+
+// WithRouter registers r so every line this command writes to stdout and
+// stderr is offered to its routes, alongside any OnStdout/OnStderr
+// callback, sink, or OnMatch/OnMatchStderr route already configured. Each
+// pipeline stage is a separate *Cmd, so call it per stage to give that
+// stage its own Router.
+// @group Streaming
+//
+// Example: with router
+//
+//	var hits []string
+//	r := execx.NewRouter()
+//	r.Route("GOOS").On(func(line string, stream execx.EventKind) { hits = append(hits, line) })
+//	_, _ = execx.Command("go", "env", "GOOS").WithRouter(r).Run()
+//	fmt.Println(len(hits) > 0)
+//	// #bool true
+func (c *Cmd) WithRouter(r *Router) *Cmd {
+	c.router = r
+	return c
+}
+
+// routerWriter feeds a Router the lines written to one stream of one
+// pipeline stage, tracking that stream's open Block (if any) independently
+// of every other stage/stream sharing the same Router.
+type routerWriter struct {
+	router *Router
+	stream EventKind
+	buf    bytes.Buffer
+
+	openRoute *Route
+	block     []string
+}
+
+func (w *routerWriter) Write(p []byte) (int, error) {
+	for _, b := range p {
+		if b != '\n' {
+			_ = w.buf.WriteByte(b)
+			continue
+		}
+		line := strings.TrimSuffix(w.buf.String(), "\r")
+		w.buf.Reset()
+		w.dispatch(line)
+	}
+	return len(p), nil
+}
+
+func (w *routerWriter) dispatch(line string) {
+	if w.openRoute != nil {
+		if w.openRoute.match(line) {
+			route, block := w.openRoute, w.block
+			w.openRoute, w.block = nil, nil
+			if route.onBlock != nil {
+				route.onBlock(block, w.stream)
+			}
+			return
+		}
+		w.block = append(w.block, line)
+		return
+	}
+
+	matched := false
+	for _, route := range w.router.routes {
+		if !route.match(line) {
+			continue
+		}
+		matched = true
+		if route.onBlock != nil {
+			w.openRoute = route
+			w.block = nil
+		} else if route.onLine != nil {
+			route.onLine(line, w.stream)
+		}
+		if w.router.mode == MatchFirst {
+			return
+		}
+	}
+	if !matched && w.router.catchall != nil {
+		w.router.catchall(line, w.stream)
+	}
+}
@@ -0,0 +1,139 @@
+package execx
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestOnMatch(t *testing.T) {
+	var hits []string
+	_, err := helperCommand("lines").
+		OnMatch(`^a$`, func(line string, groups []string) { hits = append(hits, line) }).
+		Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(hits) != 1 || hits[0] != "a" {
+		t.Fatalf("unexpected matches: %v", hits)
+	}
+}
+
+func TestOnMatchStderr(t *testing.T) {
+	var hits []string
+	_, err := helperCommand("lines").
+		OnMatchStderr(`^c$`, func(line string, groups []string) { hits = append(hits, line) }).
+		Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(hits) != 1 || hits[0] != "c" {
+		t.Fatalf("unexpected matches: %v", hits)
+	}
+}
+
+func TestOnMatchBadPattern(t *testing.T) {
+	_, err := helperCommand("lines").OnMatch("(", func(string, []string) {}).Run()
+	if err == nil {
+		t.Fatalf("expected a compile error, got nil")
+	}
+}
+
+func TestRouterLiteralPrefix(t *testing.T) {
+	var hits []string
+	r := NewRouter()
+	r.Route("a").On(func(line string, stream EventKind) { hits = append(hits, line) })
+	_, err := helperCommand("lines").WithRouter(r).Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(hits) != 1 || hits[0] != "a" {
+		t.Fatalf("unexpected matches: %v", hits)
+	}
+}
+
+func TestRouterRegexpPattern(t *testing.T) {
+	streams := map[string]EventKind{}
+	r := NewRouter()
+	r.Route(regexp.MustCompile(`^[ac]$`)).On(func(line string, stream EventKind) {
+		streams[line] = stream
+	})
+	_, err := helperCommand("lines").WithRouter(r).Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if streams["a"] != EventStdout || streams["c"] != EventStderr {
+		t.Fatalf("unexpected streams: %v", streams)
+	}
+}
+
+func TestRouterPredicatePattern(t *testing.T) {
+	var hits []string
+	r := NewRouter()
+	r.Route(func(line string) bool { return line == "b" }).
+		On(func(line string, stream EventKind) { hits = append(hits, line) })
+	_, err := helperCommand("lines").WithRouter(r).Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(hits) != 1 || hits[0] != "b" {
+		t.Fatalf("unexpected matches: %v", hits)
+	}
+}
+
+func TestRouterMatchAll(t *testing.T) {
+	var first, second []string
+	r := NewRouter(WithMatchMode(MatchAll))
+	r.Route("a").On(func(line string, stream EventKind) { first = append(first, line) })
+	r.Route(func(line string) bool { return line == "a" }).
+		On(func(line string, stream EventKind) { second = append(second, line) })
+	_, err := helperCommand("lines").WithRouter(r).Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(first) != 1 {
+		t.Fatalf("expected the first route to also run under MatchAll, got %v", first)
+	}
+	if len(second) != 1 {
+		t.Fatalf("expected the second route to also run on the same line under MatchAll, got %v", second)
+	}
+}
+
+func TestRouterCatchall(t *testing.T) {
+	var misses []string
+	r := NewRouter()
+	r.Route("nomatch").On(func(string, EventKind) {})
+	r.Catchall(func(line string, stream EventKind) { misses = append(misses, line) })
+	_, err := helperCommand("lines").WithRouter(r).Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(misses) != 3 {
+		t.Fatalf("expected every unmatched line across both streams, got %v", misses)
+	}
+}
+
+func TestRouterBlock(t *testing.T) {
+	var blocks [][]string
+	r := NewRouter()
+	r.Route("---").Block(func(lines []string, stream EventKind) { blocks = append(blocks, lines) })
+	_, err := helperCommand("block").WithRouter(r).Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(blocks) != 1 {
+		t.Fatalf("expected exactly one captured block, got %v", blocks)
+	}
+	if len(blocks[0]) != 2 || blocks[0][0] != "inside1" || blocks[0][1] != "inside2" {
+		t.Fatalf("unexpected block contents: %v", blocks[0])
+	}
+}
+
+func TestRouterUnsupportedPattern(t *testing.T) {
+	r := NewRouter()
+	r.Route(42)
+	_, err := helperCommand("lines").WithRouter(r).Run()
+	if err == nil || !strings.Contains(err.Error(), "unsupported pattern type") {
+		t.Fatalf("expected an unsupported pattern type error, got %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+package execx
+
+import "time"
+
+// Rusage reports resource usage for one finished process, normalized
+// across platforms from whatever os/exec exposes via
+// ProcessState.SysUsage(): a *syscall.Rusage on unix and windows, nothing
+// at all elsewhere. Fields with no equivalent on the platform that
+// produced them (MaxRSSBytes and the fault/block/context-switch counters
+// on Windows) are left zero rather than guessed at.
+type Rusage struct {
+	UserTime               time.Duration
+	SystemTime             time.Duration
+	MaxRSSBytes            int64
+	MinorFaults            int64
+	MajorFaults            int64
+	InBlock                int64
+	OutBlock               int64
+	VoluntaryCtxSwitches   int64
+	InvoluntaryCtxSwitches int64
+}
+
+// OnMetrics registers a callback invoked once per stage, right after that
+// stage's process exits, with the Rusage collected for it. Unlike
+// Result.Rusage it fires for every stage of a pipeline as each one
+// finishes, not only the one PipelineResults or Run ends up reporting.
+// @group Results
+//
+// Example: on metrics
+//
+//	var usage execx.Rusage
+//	_, _ = execx.Command("go", "env", "GOOS").
+//		OnMetrics(func(u execx.Rusage) { usage = u }).
+//		Run()
+//	fmt.Println(usage.UserTime >= 0)
+//	// #bool true
+func (c *Cmd) OnMetrics(fn func(Rusage)) *Cmd {
+	c.onMetrics = fn
+	return c
+}
@@ -0,0 +1,11 @@
+//go:build !unix && !windows
+
+package execx
+
+import "os"
+
+// rusageFromState returns a zero Rusage: platforms outside unix and
+// windows don't expose a SysUsage() this package knows how to decode.
+func rusageFromState(_ *os.ProcessState) Rusage {
+	return Rusage{}
+}
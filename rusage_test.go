@@ -0,0 +1,51 @@
+package execx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResultElapsedWallMeasuresSpawnToWait(t *testing.T) {
+	res, err := helperCommand("sleep", "50").Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res.ElapsedWall < 50*time.Millisecond {
+		t.Fatalf("expected ElapsedWall >= 50ms, got %v", res.ElapsedWall)
+	}
+}
+
+func TestOnMetricsFiresOncePerStage(t *testing.T) {
+	var calls int
+	var lastUsage Rusage
+	res, err := helperCommand("mix").
+		OnMetrics(func(u Rusage) {
+			calls++
+			lastUsage = u
+		}).
+		Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected OnMetrics to fire once, got %d calls", calls)
+	}
+	if lastUsage != res.Rusage {
+		t.Fatalf("expected OnMetrics to receive the stage's Rusage, got %+v vs %+v", lastUsage, res.Rusage)
+	}
+}
+
+func TestPipelineResultsIncludeRusagePerStage(t *testing.T) {
+	results, err := helperPipe(helperCommand("echo", "go"), "cat").PipelineResults()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 stage results, got %d", len(results))
+	}
+	for i, res := range results {
+		if res.ElapsedWall <= 0 {
+			t.Fatalf("expected stage %d to have a positive ElapsedWall, got %v", i, res.ElapsedWall)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+//go:build unix
+
+package execx
+
+import (
+	"os"
+	"runtime"
+	"syscall"
+	"time"
+)
+
+// rusageFromState extracts Rusage from a finished process's ProcessState.
+// syscall.Rusage has the same field names on every unix Go supports, but
+// Maxrss is reported in kilobytes on Linux and bytes everywhere else
+// (Darwin and the BSDs), so it's the one field that needs a GOOS check.
+func rusageFromState(state *os.ProcessState) Rusage {
+	if state == nil {
+		return Rusage{}
+	}
+	ru, ok := state.SysUsage().(*syscall.Rusage)
+	if !ok || ru == nil {
+		return Rusage{}
+	}
+	return rusageFromSyscall(ru)
+}
+
+// rusageFromSyscall normalizes a raw *syscall.Rusage into Rusage, shared
+// by rusageFromState (Cmd's own ProcessState.SysUsage()) and the
+// subreaper, which only ever gets a *syscall.Rusage out of wait4 and has
+// no ProcessState to wrap it in.
+func rusageFromSyscall(ru *syscall.Rusage) Rusage {
+	maxRSS := int64(ru.Maxrss)
+	if runtime.GOOS == "linux" {
+		maxRSS *= 1024
+	}
+	return Rusage{
+		UserTime:               timevalDuration(ru.Utime),
+		SystemTime:             timevalDuration(ru.Stime),
+		MaxRSSBytes:            maxRSS,
+		MinorFaults:            int64(ru.Minflt),
+		MajorFaults:            int64(ru.Majflt),
+		InBlock:                int64(ru.Inblock),
+		OutBlock:               int64(ru.Oublock),
+		VoluntaryCtxSwitches:   int64(ru.Nvcsw),
+		InvoluntaryCtxSwitches: int64(ru.Nivcsw),
+	}
+}
+
+func timevalDuration(tv syscall.Timeval) time.Duration {
+	return time.Duration(tv.Sec)*time.Second + time.Duration(tv.Usec)*time.Microsecond
+}
+
+// SysRusage returns the raw *syscall.Rusage wait4 collected for this
+// process, the same struct Rusage summarizes into cross-platform
+// fields. Nil if the process never started.
+// @group Results
+//
+// Example: sys rusage
+//
+//	res, _ := execx.Command("go", "env", "GOOS").Run()
+//	fmt.Println(res.SysRusage() != nil)
+//	// #bool true
+func (r Result) SysRusage() *syscall.Rusage {
+	if r.processState == nil {
+		return nil
+	}
+	ru, _ := r.processState.SysUsage().(*syscall.Rusage)
+	return ru
+}
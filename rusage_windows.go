@@ -0,0 +1,50 @@
+//go:build windows
+
+package execx
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// rusageFromState extracts Rusage from a finished process's ProcessState.
+// Windows has no rlimit-style struct; os/exec populates
+// ProcessState.SysUsage() with a *syscall.Rusage carrying the
+// GetProcessTimes kernel/user FILETIMEs instead, so MaxRSSBytes and the
+// fault/block/context-switch counters stay zero here.
+func rusageFromState(state *os.ProcessState) Rusage {
+	if state == nil {
+		return Rusage{}
+	}
+	ru, ok := state.SysUsage().(*syscall.Rusage)
+	if !ok || ru == nil {
+		return Rusage{}
+	}
+	return Rusage{
+		UserTime:   filetimeDuration(ru.UserTime),
+		SystemTime: filetimeDuration(ru.KernelTime),
+	}
+}
+
+func filetimeDuration(ft syscall.Filetime) time.Duration {
+	return time.Duration(ft.Nanoseconds())
+}
+
+// SysRusage returns the raw *syscall.Rusage os/exec populated for this
+// process from GetProcessTimes, the same struct Rusage summarizes into
+// cross-platform fields. Nil if the process never started.
+// @group Results
+//
+// Example: sys rusage
+//
+//	res, _ := execx.Command("go", "env", "GOOS").Run()
+//	fmt.Println(res.SysRusage() != nil)
+//	// #bool true
+func (r Result) SysRusage() *syscall.Rusage {
+	if r.processState == nil {
+		return nil
+	}
+	ru, _ := r.processState.SysUsage().(*syscall.Rusage)
+	return ru
+}
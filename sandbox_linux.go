@@ -0,0 +1,164 @@
+//go:build linux
+
+package execx
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// prSetNoNewPrivs is PR_SET_NO_NEW_PRIVS from linux/prctl.h. The syscall
+// package doesn't export prctl's PR_* option constants.
+const prSetNoNewPrivs = 38
+
+func sandboxSupported() error {
+	return nil
+}
+
+// applySandbox configures cmd's SysProcAttr for spec's namespaces, ID
+// mappings, chroot, ambient capabilities, and NoNewPrivs, and rewrites
+// cmd.Path/Args to apply spec.Rlimits via the same ulimit shell-wrap
+// wrapForLimits uses for WithLimits.
+func applySandbox(cmd *exec.Cmd, spec SandboxSpec) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	attr := cmd.SysProcAttr
+	attr.Cloneflags |= sandboxCloneFlags(spec.Namespaces)
+	if len(spec.UIDMappings) > 0 {
+		attr.UidMappings = sandboxIDMappings(spec.UIDMappings)
+	}
+	if len(spec.GIDMappings) > 0 {
+		attr.GidMappings = sandboxIDMappings(spec.GIDMappings)
+	}
+	if spec.Chroot != "" {
+		attr.Chroot = spec.Chroot
+	}
+	if len(spec.AmbientCaps) > 0 {
+		attr.AmbientCaps = spec.AmbientCaps
+	}
+	if spec.Rlimits != (SandboxRlimits{}) {
+		cmd.Path, cmd.Args = wrapForSandboxRlimits(cmd.Path, cmd.Args[1:], spec.Rlimits)
+	}
+	if spec.NoNewPrivs {
+		wrapForNoNewPrivs(cmd)
+	}
+}
+
+// sandboxNoNewPrivsEnv marks a re-executed child that must set
+// PR_SET_NO_NEW_PRIVS via prctl(2) before exec'ing into its real target.
+// syscall.SysProcAttr has no field for it (unlike Chroot or AmbientCaps),
+// and os/exec gives no hook to run code between fork and exec, so
+// wrapForNoNewPrivs re-execs the current binary: that copy sets the bit
+// in sandboxNoNewPrivsReexec and then execs the original argv, which
+// inherits NoNewPrivs across exec like any other process attribute.
+const sandboxNoNewPrivsEnv = "_EXECX_SANDBOX_NO_NEW_PRIVS"
+
+func init() {
+	if os.Getenv(sandboxNoNewPrivsEnv) == "1" {
+		sandboxNoNewPrivsReexec()
+	}
+}
+
+// wrapForNoNewPrivs rewrites cmd to re-exec the current binary with
+// cmd's original argv tacked on, and tags the child via
+// sandboxNoNewPrivsEnv so sandboxNoNewPrivsReexec picks it up on entry.
+func wrapForNoNewPrivs(cmd *exec.Cmd) {
+	self, err := os.Executable()
+	if err != nil {
+		return
+	}
+	cmd.Args = append([]string{self, cmd.Path}, cmd.Args[1:]...)
+	cmd.Path = self
+	cmd.Env = append(cmd.Env, sandboxNoNewPrivsEnv+"=1")
+}
+
+// sandboxNoNewPrivsReexec sets PR_SET_NO_NEW_PRIVS and execs into
+// os.Args[1:], the target argv wrapForNoNewPrivs recorded. It never
+// returns; any failure exits the re-exec shim with a non-zero status.
+func sandboxNoNewPrivsReexec() {
+	os.Unsetenv(sandboxNoNewPrivsEnv)
+	if _, _, errno := syscall.Syscall(syscall.SYS_PRCTL, prSetNoNewPrivs, 1, 0); errno != 0 {
+		fmt.Fprintf(os.Stderr, "execx: prctl(PR_SET_NO_NEW_PRIVS): %v\n", errno)
+		os.Exit(127)
+	}
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "execx: sandbox no-new-privs reexec missing target argv")
+		os.Exit(127)
+	}
+	target := os.Args[1:]
+	path, err := exec.LookPath(target[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "execx: %v\n", err)
+		os.Exit(127)
+	}
+	if err := syscall.Exec(path, target, os.Environ()); err != nil {
+		fmt.Fprintf(os.Stderr, "execx: exec: %v\n", err)
+		os.Exit(127)
+	}
+}
+
+func sandboxCloneFlags(ns SandboxNamespaces) uintptr {
+	var flags uintptr
+	if ns.User {
+		flags |= syscall.CLONE_NEWUSER
+	}
+	if ns.Mount {
+		flags |= syscall.CLONE_NEWNS
+	}
+	if ns.PID {
+		flags |= syscall.CLONE_NEWPID
+	}
+	if ns.Net {
+		flags |= syscall.CLONE_NEWNET
+	}
+	if ns.UTS {
+		flags |= syscall.CLONE_NEWUTS
+	}
+	if ns.IPC {
+		flags |= syscall.CLONE_NEWIPC
+	}
+	return flags
+}
+
+func sandboxIDMappings(mappings []SandboxIDMap) []syscall.SysProcIDMap {
+	out := make([]syscall.SysProcIDMap, len(mappings))
+	for i, m := range mappings {
+		out[i] = syscall.SysProcIDMap{ContainerID: m.ContainerID, HostID: m.HostID, Size: m.Size}
+	}
+	return out
+}
+
+// wrapForSandboxRlimits mirrors wrapForLimits for SandboxRlimits, which
+// additionally caps RLIMIT_CORE.
+func wrapForSandboxRlimits(name string, args []string, r SandboxRlimits) (string, []string) {
+	var script strings.Builder
+	if r.MemoryBytes > 0 {
+		fmt.Fprintf(&script, "ulimit -v %d; ", r.MemoryBytes/1024)
+	}
+	if r.CPUTime > 0 {
+		fmt.Fprintf(&script, "ulimit -t %d; ", int64(r.CPUTime/time.Second))
+	}
+	if r.CoreBytes > 0 {
+		fmt.Fprintf(&script, "ulimit -c %d; ", r.CoreBytes/512)
+	}
+	if r.OpenFiles > 0 {
+		fmt.Fprintf(&script, "ulimit -n %d; ", r.OpenFiles)
+	}
+	if r.Processes > 0 {
+		fmt.Fprintf(&script, "ulimit -u %d; ", r.Processes)
+	}
+	script.WriteString(`exec "$0" "$@"`)
+
+	wrapped := append([]string{name}, args...)
+	shArgs := append([]string{"-c", script.String()}, wrapped...)
+	// cmd.Args is assigned directly rather than going back through
+	// exec.Command (which would otherwise prepend argv[0] for us), so
+	// Args[0] has to be included here too, or the shell never sees its
+	// own "-c" flag.
+	return "/bin/sh", append([]string{"/bin/sh"}, shArgs...)
+}
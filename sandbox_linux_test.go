@@ -0,0 +1,112 @@
+//go:build linux
+
+package execx
+
+import (
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestApplySandboxSetsCloneflagsAndChroot(t *testing.T) {
+	cmd := helperCommand("echo", "hi").execCmd()
+	applySandbox(cmd, SandboxSpec{
+		Namespaces: SandboxNamespaces{PID: true, UTS: true, IPC: true},
+		Chroot:     "/tmp",
+	})
+	if cmd.SysProcAttr.Cloneflags&syscall.CLONE_NEWPID == 0 {
+		t.Fatalf("expected CLONE_NEWPID set, got %#x", cmd.SysProcAttr.Cloneflags)
+	}
+	if cmd.SysProcAttr.Cloneflags&syscall.CLONE_NEWUTS == 0 {
+		t.Fatalf("expected CLONE_NEWUTS set, got %#x", cmd.SysProcAttr.Cloneflags)
+	}
+	if cmd.SysProcAttr.Cloneflags&syscall.CLONE_NEWIPC == 0 {
+		t.Fatalf("expected CLONE_NEWIPC set, got %#x", cmd.SysProcAttr.Cloneflags)
+	}
+	if cmd.SysProcAttr.Chroot != "/tmp" {
+		t.Fatalf("expected chroot /tmp, got %q", cmd.SysProcAttr.Chroot)
+	}
+}
+
+func TestApplySandboxWrapsArgvForNoNewPrivs(t *testing.T) {
+	cmd := helperCommand("echo", "hi").execCmd()
+	origPath := cmd.Path
+	applySandbox(cmd, SandboxSpec{NoNewPrivs: true})
+	self, err := os.Executable()
+	if err != nil {
+		t.Fatalf("os.Executable: %v", err)
+	}
+	if cmd.Path != self {
+		t.Fatalf("expected re-exec into %q, got %q", self, cmd.Path)
+	}
+	if len(cmd.Args) < 2 || cmd.Args[1] != origPath {
+		t.Fatalf("expected wrapped argv to still target %q, got %v", origPath, cmd.Args)
+	}
+	found := false
+	for _, kv := range cmd.Env {
+		if kv == sandboxNoNewPrivsEnv+"=1" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected %s=1 in cmd.Env, got %v", sandboxNoNewPrivsEnv, cmd.Env)
+	}
+}
+
+func TestApplySandboxWrapsArgvForRlimits(t *testing.T) {
+	cmd := helperCommand("echo", "hi").execCmd()
+	origPath := cmd.Path
+	applySandbox(cmd, SandboxSpec{Rlimits: SandboxRlimits{OpenFiles: 64, CoreBytes: 0}})
+	if cmd.Path != "/bin/sh" {
+		t.Fatalf("expected /bin/sh, got %q", cmd.Path)
+	}
+	if cmd.Args[0] != "/bin/sh" {
+		t.Fatalf("expected Args[0] to be /bin/sh, got %v", cmd.Args)
+	}
+	if len(cmd.Args) < 4 || cmd.Args[3] != origPath {
+		t.Fatalf("expected wrapped argv to still exec %q, got %v", origPath, cmd.Args)
+	}
+}
+
+func TestSandboxRlimitsActuallyRuns(t *testing.T) {
+	res, err := helperCommand("echo", "hi").
+		Sandbox(SandboxSpec{Rlimits: SandboxRlimits{OpenFiles: 64}}).
+		Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res.ExitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%q)", res.ExitCode, res.Stderr)
+	}
+	if res.Stdout != "hi" {
+		t.Fatalf("expected stdout %q, got %q", "hi", res.Stdout)
+	}
+}
+
+func TestSandboxComposesWithRlimitsAndNamespaces(t *testing.T) {
+	res, err := helperCommand("echo", "hi").
+		Sandbox(SandboxSpec{
+			Namespaces: SandboxNamespaces{UTS: true},
+			Rlimits:    SandboxRlimits{OpenFiles: 64},
+		}).
+		Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res.ExitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%q)", res.ExitCode, res.Stderr)
+	}
+}
+
+func TestSandboxComposesWithSetsid(t *testing.T) {
+	res, err := helperCommand("echo", "hi").
+		Setsid(true).
+		Sandbox(SandboxSpec{Namespaces: SandboxNamespaces{UTS: true}}).
+		Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res.ExitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d", res.ExitCode)
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+
+package execx
+
+import "os/exec"
+
+func sandboxSupported() error {
+	return ErrSandboxUnsupported
+}
+
+func applySandbox(_ *exec.Cmd, _ SandboxSpec) {}
@@ -0,0 +1,34 @@
+package execx
+
+import (
+	"errors"
+	"os/exec"
+	"runtime"
+	"testing"
+)
+
+func TestSandboxNonLinuxUnsupported(t *testing.T) {
+	if runtime.GOOS == "linux" {
+		t.Skip("only relevant off Linux")
+	}
+	_, err := helperCommand("echo", "hi").Sandbox(SandboxSpec{}).Run()
+	if !errors.Is(err, ErrSandboxUnsupported) {
+		t.Fatalf("expected ErrSandboxUnsupported, got %v", err)
+	}
+}
+
+func TestOnExecCmdReceivesBuiltCmd(t *testing.T) {
+	var got *exec.Cmd
+	res, err := helperCommand("echo", "hi").OnExecCmd(func(cmd *exec.Cmd) {
+		got = cmd
+	}).Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got == nil {
+		t.Fatalf("expected OnExecCmd to be called")
+	}
+	if res.ExitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d", res.ExitCode)
+	}
+}
@@ -17,3 +17,66 @@ func signalFromState(state *os.ProcessState) os.Signal {
 	}
 	return nil
 }
+
+func coreDumpedFromState(state *os.ProcessState) bool {
+	if state == nil {
+		return false
+	}
+	waitStatus, ok := state.Sys().(syscall.WaitStatus)
+	return ok && waitStatus.CoreDump()
+}
+
+func stoppedFromState(state *os.ProcessState) (bool, os.Signal) {
+	if state == nil {
+		return false, nil
+	}
+	waitStatus, ok := state.Sys().(syscall.WaitStatus)
+	if !ok || !waitStatus.Stopped() {
+		return false, nil
+	}
+	return true, waitStatus.StopSignal()
+}
+
+// IsTransient reports whether this result looks like a transient,
+// retry-safe failure: SIGTERM/SIGINT, a cancelled or expired context, or
+// the POSIX shell exit codes for those signals (143, 130).
+// @group Results
+//
+// Example: is transient
+//
+//	res, _ := execx.Command("go", "env", "GOOS").Run()
+//	fmt.Println(res.IsTransient())
+//	// #bool false
+func (r Result) IsTransient() bool {
+	switch {
+	case r.IsSignal(syscall.SIGTERM), r.IsSignal(syscall.SIGINT):
+		return true
+	case r.TimedOut(), r.IsCanceled():
+		return true
+	case r.ExitCode == 143, r.ExitCode == 130:
+		return true
+	}
+	return false
+}
+
+// IsCrash reports whether this result looks like the process crashed
+// rather than exited or was deliberately killed: SIGSEGV, SIGBUS,
+// SIGABRT, SIGFPE, SIGILL, or a core dump.
+// @group Results
+//
+// Example: is crash
+//
+//	res, _ := execx.Command("go", "env", "GOOS").Run()
+//	fmt.Println(res.IsCrash())
+//	// #bool false
+func (r Result) IsCrash() bool {
+	if r.CoreDumped() {
+		return true
+	}
+	switch {
+	case r.IsSignal(syscall.SIGSEGV), r.IsSignal(syscall.SIGBUS),
+		r.IsSignal(syscall.SIGABRT), r.IsSignal(syscall.SIGFPE), r.IsSignal(syscall.SIGILL):
+		return true
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+//go:build windows
+
+package execx
+
+import "os"
+
+// signalFromState always returns nil: Windows has no POSIX signal
+// semantics for exec.Cmd to report.
+func signalFromState(_ *os.ProcessState) os.Signal {
+	return nil
+}
+
+func coreDumpedFromState(_ *os.ProcessState) bool {
+	return false
+}
+
+func stoppedFromState(_ *os.ProcessState) (bool, os.Signal) {
+	return false, nil
+}
+
+// IsTransient reports whether this result looks like a transient,
+// retry-safe failure. Windows has no signal semantics, so this only
+// recognizes a cancelled or expired context.
+// @group Results
+//
+// Example: is transient
+//
+//	res, _ := execx.Command("go", "env", "GOOS").Run()
+//	fmt.Println(res.IsTransient())
+//	// #bool false
+func (r Result) IsTransient() bool {
+	return r.TimedOut() || r.IsCanceled()
+}
+
+// IsCrash reports whether this result looks like the process crashed.
+// Windows has no signal semantics or core dumps, so this is always
+// false.
+// @group Results
+//
+// Example: is crash
+//
+//	res, _ := execx.Command("go", "env", "GOOS").Run()
+//	fmt.Println(res.IsCrash())
+//	// #bool false
+func (r Result) IsCrash() bool {
+	return false
+}
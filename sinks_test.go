@@ -0,0 +1,103 @@
+package execx
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAddStdoutSinkReceivesLines(t *testing.T) {
+	var sink strings.Builder
+	_, err := helperCommand("lines").AddStdoutSink("log", &sink).Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if sink.String() != "a\nb\n" {
+		t.Fatalf("unexpected sink output: %q", sink.String())
+	}
+}
+
+func TestAddStderrSinkReceivesLines(t *testing.T) {
+	var sink strings.Builder
+	_, err := helperCommand("lines").AddStderrSink("log", &sink).Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if sink.String() != "c\n" {
+		t.Fatalf("unexpected sink output: %q", sink.String())
+	}
+}
+
+func TestMultipleSinksEachReceiveIndependentCopies(t *testing.T) {
+	var first, second strings.Builder
+	_, err := helperCommand("lines").
+		AddStdoutSink("first", &first).
+		AddStdoutSink("second", &second, WithSinkPrefix("> ")).
+		Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if first.String() != "a\nb\n" {
+		t.Fatalf("unexpected first sink output: %q", first.String())
+	}
+	if second.String() != "> a\n> b\n" {
+		t.Fatalf("unexpected second sink output: %q", second.String())
+	}
+}
+
+func TestSinkMaskRewritesLines(t *testing.T) {
+	var sink strings.Builder
+	_, err := helperCommand("lines").
+		AddStdoutSink("masked", &sink, WithSinkMask(func(line string) string {
+			return strings.ReplaceAll(line, "a", "*")
+		})).
+		Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if sink.String() != "*\nb\n" {
+		t.Fatalf("unexpected sink output: %q", sink.String())
+	}
+}
+
+func TestSinkRateLimitDropsLines(t *testing.T) {
+	var sink strings.Builder
+	_, err := helperCommand("lines").
+		AddStdoutSink("throttled", &sink, WithSinkRateLimit(time.Hour)).
+		Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if sink.String() != "a\n" {
+		t.Fatalf("expected only the first line through the rate limit, got %q", sink.String())
+	}
+}
+
+func TestSinkJSONLinesAddsEnvelope(t *testing.T) {
+	var sink strings.Builder
+	_, err := helperCommand("json").
+		AddStdoutSink("structured", &sink, WithSinkJSONLines()).
+		Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	out := sink.String()
+	for _, field := range []string{`"msg":"hi"`, `"stream":"stdout"`, `"stage":0`, `"ts":"`} {
+		if !strings.Contains(out, field) {
+			t.Fatalf("expected sink output to contain %q, got %q", field, out)
+		}
+	}
+}
+
+func TestSinkJSONLinesWrapsNonJSONLine(t *testing.T) {
+	var sink strings.Builder
+	_, err := helperCommand("lines").
+		AddStdoutSink("structured", &sink, WithSinkJSONLines()).
+		Run()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(sink.String(), `"line":"a"`) {
+		t.Fatalf("expected non-JSON line wrapped under \"line\", got %q", sink.String())
+	}
+}
@@ -0,0 +1,81 @@
+package execx
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrSubreaperUnsupported is returned by EnableSubreaper on platforms
+// without Linux's PR_SET_CHILD_SUBREAPER.
+var ErrSubreaperUnsupported = errors.New("execx: Subreaper is not supported on this platform")
+
+var (
+	orphanExitMu   sync.Mutex
+	orphanExitFunc func(pid int, result Result)
+)
+
+// OnOrphanExit registers fn to be called whenever the subreaper goroutine
+// reaps an orphaned descendant: a grandchild reparented to this process
+// (because EnableSubreaper is active) once its immediate parent already
+// exited, for example a Daemonize grandchild after its setsid launcher
+// exits. fn receives the reaped pid and a Result built from the pid's
+// wait4 status, the same way a normally-Waited stage's Result is built.
+// Only one callback is kept; a later call replaces an earlier one. On
+// platforms other than Linux the reaper never runs, so fn is never
+// called.
+// @group Process
+//
+// Example: on orphan exit
+//
+//	execx.OnOrphanExit(func(pid int, result execx.Result) {})
+func OnOrphanExit(fn func(pid int, result Result)) {
+	orphanExitMu.Lock()
+	orphanExitFunc = fn
+	orphanExitMu.Unlock()
+}
+
+// onOrphanExit invokes the callback registered via OnOrphanExit, if any.
+func onOrphanExit(pid int, result Result) {
+	orphanExitMu.Lock()
+	fn := orphanExitFunc
+	orphanExitMu.Unlock()
+	if fn != nil {
+		fn(pid, result)
+	}
+}
+
+// StopSubreaper disables subreaper mode enabled by a prior
+// EnableSubreaper call.
+type StopSubreaper func() error
+
+// EnableSubreaper marks this process as a Linux child subreaper, so any
+// descendant that would otherwise be orphaned onto init once its parent
+// exits (for example a Daemonize grandchild, once the intermediate
+// setsid process exits) is instead adopted by this process, and starts
+// a package-level goroutine that reaps those adopted orphans as they
+// exit so they never pile up as zombies.
+//
+// The reaper only waits on pids it discovers are this process's zombie
+// children but weren't started by a Cmd this package is actively
+// running; it never calls wait4(-1, ...), which would otherwise race
+// the os/exec package's own wait4 calls for every other Run/Start/Wait
+// in this process. Enabling subreaper mode is process-wide and affects
+// every execx command running in it, so it's typically done once near
+// the top of main, e.g. in an init process or supervisor.
+//
+// Call the returned stop function, typically via defer, to disable the
+// reaper goroutine and clear the subreaper attribute. On platforms other
+// than Linux this returns ErrSubreaperUnsupported.
+// @group Process
+//
+// Example: enable subreaper
+//
+//	stop, err := execx.EnableSubreaper()
+//	fmt.Println(err == nil || errors.Is(err, execx.ErrSubreaperUnsupported))
+//	if stop != nil {
+//		_ = stop()
+//	}
+//	// #bool true
+func EnableSubreaper() (StopSubreaper, error) {
+	return enableSubreaper()
+}
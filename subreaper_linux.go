@@ -0,0 +1,171 @@
+//go:build linux
+
+package execx
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+const prSetChildSubreaper = 36 // linux/prctl.h PR_SET_CHILD_SUBREAPER
+
+var (
+	subreaperMu     sync.Mutex
+	subreaperActive bool
+	ownedPids       = map[int]struct{}{}
+)
+
+// registerOwnedPid records a pid this package just started so the
+// subreaper goroutine never tries to reap it itself; the stage that
+// started it will reap it via its own cmd.Wait.
+func registerOwnedPid(pid int) {
+	subreaperMu.Lock()
+	ownedPids[pid] = struct{}{}
+	subreaperMu.Unlock()
+}
+
+// unregisterOwnedPid releases a pid once its owning stage has reaped it
+// with cmd.Wait.
+func unregisterOwnedPid(pid int) {
+	subreaperMu.Lock()
+	delete(ownedPids, pid)
+	subreaperMu.Unlock()
+}
+
+func enableSubreaper() (StopSubreaper, error) {
+	subreaperMu.Lock()
+	if subreaperActive {
+		subreaperMu.Unlock()
+		return nil, errors.New("execx: subreaper already enabled")
+	}
+	subreaperActive = true
+	subreaperMu.Unlock()
+
+	if _, _, errno := syscall.Syscall(syscall.SYS_PRCTL, prSetChildSubreaper, 1, 0); errno != 0 {
+		subreaperMu.Lock()
+		subreaperActive = false
+		subreaperMu.Unlock()
+		return nil, fmt.Errorf("execx: enable subreaper: %w", errno)
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGCHLD)
+	done := make(chan struct{})
+	go reapLoop(sigCh, done)
+
+	var stopOnce sync.Once
+	stop := func() error {
+		var err error
+		stopOnce.Do(func() {
+			subreaperMu.Lock()
+			subreaperActive = false
+			subreaperMu.Unlock()
+			signal.Stop(sigCh)
+			close(done)
+			if _, _, errno := syscall.Syscall(syscall.SYS_PRCTL, prSetChildSubreaper, 0, 0); errno != 0 {
+				err = fmt.Errorf("execx: disable subreaper: %w", errno)
+			}
+		})
+		return err
+	}
+	return stop, nil
+}
+
+func reapLoop(sigCh <-chan os.Signal, done <-chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		case <-sigCh:
+			reapOrphans()
+		}
+	}
+}
+
+func reapOrphans() {
+	for _, pid := range orphanedZombiePids() {
+		var ws syscall.WaitStatus
+		var ru syscall.Rusage
+		if _, err := syscall.Wait4(pid, &ws, syscall.WNOHANG, &ru); err != nil {
+			continue
+		}
+		onOrphanExit(pid, resultFromWaitStatus(ws, &ru))
+	}
+}
+
+// resultFromWaitStatus builds the Result an orphan's OnOrphanExit
+// callback sees from a raw wait4 status and rusage: there's no
+// os.ProcessState to delegate to here, only what wait4 itself reported.
+func resultFromWaitStatus(ws syscall.WaitStatus, ru *syscall.Rusage) Result {
+	res := Result{ExitCode: -1, Rusage: rusageFromSyscall(ru)}
+	switch {
+	case ws.Exited():
+		res.ExitCode = ws.ExitStatus()
+	case ws.Signaled():
+		res.signal = ws.Signal()
+		res.coreDumped = ws.CoreDump()
+	}
+	return res
+}
+
+// orphanedZombiePids scans /proc for this process's zombie children that
+// weren't started by a Cmd this package is currently running, so the
+// reaper only ever wait4's pids os/exec has no stake in.
+func orphanedZombiePids() []int {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil
+	}
+	self := os.Getpid()
+	var zombies []int
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		ppid, state, ok := readProcStat(pid)
+		if !ok || state != "Z" || ppid != self {
+			continue
+		}
+		subreaperMu.Lock()
+		_, owned := ownedPids[pid]
+		subreaperMu.Unlock()
+		if owned {
+			continue
+		}
+		zombies = append(zombies, pid)
+	}
+	return zombies
+}
+
+// readProcStat parses the ppid and state fields out of /proc/<pid>/stat.
+// The process name field is parenthesized and may itself contain spaces
+// or parens, so the remaining fields are read after its closing paren
+// rather than by a naive whitespace split.
+func readProcStat(pid int) (ppid int, state string, ok bool) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, "", false
+	}
+	line := string(data)
+	closeParen := strings.LastIndexByte(line, ')')
+	if closeParen < 0 || closeParen+2 >= len(line) {
+		return 0, "", false
+	}
+	fields := strings.Fields(line[closeParen+2:])
+	if len(fields) < 2 {
+		return 0, "", false
+	}
+	state = fields[0]
+	ppid, err = strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, "", false
+	}
+	return ppid, state, true
+}
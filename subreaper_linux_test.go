@@ -0,0 +1,105 @@
+//go:build linux
+
+package execx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEnableSubreaperLifecycle(t *testing.T) {
+	stop, err := EnableSubreaper()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := stop(); err != nil {
+		t.Fatalf("expected stop to succeed, got %v", err)
+	}
+}
+
+func TestEnableSubreaperTwiceFails(t *testing.T) {
+	stop, err := EnableSubreaper()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer stop()
+
+	if _, err := EnableSubreaper(); err == nil {
+		t.Fatalf("expected an error enabling subreaper twice")
+	}
+}
+
+func TestSubreaperReapsOrphan(t *testing.T) {
+	stop, err := EnableSubreaper()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer stop()
+
+	d, err := helperCommand("echo", "hi").Daemonize()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, _, ok := readProcStat(d.Pid); !ok {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("expected daemon pid %d to be reaped", d.Pid)
+}
+
+func TestOnOrphanExitFires(t *testing.T) {
+	stop, err := EnableSubreaper()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer stop()
+	defer OnOrphanExit(nil)
+
+	type reaped struct {
+		pid    int
+		result Result
+	}
+	got := make(chan reaped, 1)
+	OnOrphanExit(func(pid int, result Result) {
+		got <- reaped{pid: pid, result: result}
+	})
+
+	d, err := helperCommand("echo", "hi").Daemonize()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	select {
+	case r := <-got:
+		if r.pid != d.Pid {
+			t.Fatalf("expected pid %d, got %d", d.Pid, r.pid)
+		}
+		if r.result.ExitCode != 0 {
+			t.Fatalf("expected exit code 0, got %d", r.result.ExitCode)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("expected OnOrphanExit to fire for daemon pid %d", d.Pid)
+	}
+}
+
+func TestOwnedPidSkippedBySubreaper(t *testing.T) {
+	stop, err := EnableSubreaper()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer stop()
+
+	proc := helperCommand("sleep", "100").Start()
+	pid := proc.pipeline.stages[0].cmd.Process.Pid
+	registerOwnedPid(pid)
+	time.Sleep(50 * time.Millisecond)
+
+	if err := proc.Interrupt(); err != nil {
+		t.Fatalf("interrupt: %v", err)
+	}
+	_, _ = proc.Wait()
+}
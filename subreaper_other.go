@@ -0,0 +1,10 @@
+//go:build !linux
+
+package execx
+
+func registerOwnedPid(pid int)   {}
+func unregisterOwnedPid(pid int) {}
+
+func enableSubreaper() (StopSubreaper, error) {
+	return nil, ErrSubreaperUnsupported
+}
@@ -0,0 +1,17 @@
+package execx
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+)
+
+func TestEnableSubreaperUnsupported(t *testing.T) {
+	if runtime.GOOS == "linux" {
+		t.Skip("subreaper is supported on linux")
+	}
+	_, err := EnableSubreaper()
+	if !errors.Is(err, ErrSubreaperUnsupported) {
+		t.Fatalf("expected ErrSubreaperUnsupported, got %v", err)
+	}
+}
@@ -0,0 +1,519 @@
+package execx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+)
+
+// RestartPolicy controls when a Supervisor restarts a managed command.
+type RestartPolicy int
+
+const (
+	// RestartOnFailure restarts only when the command exits non-zero or errors.
+	RestartOnFailure RestartPolicy = iota
+	// RestartAlways restarts regardless of exit code.
+	RestartAlways
+	// RestartNever never restarts; the command stops after one run.
+	RestartNever
+)
+
+// Backoff configures the exponential delay a Supervisor waits between
+// restart attempts for one command. Delay grows Min, Min*Factor,
+// Min*Factor^2, ... capped at Max, with up to 20% jitter added on top so
+// a fleet of commands crash-looping together doesn't retry in lockstep.
+// Zero fields fall back to Min=1s, Max=30s, Factor=2.
+type Backoff struct {
+	Min    time.Duration
+	Max    time.Duration
+	Factor float64
+}
+
+func (b Backoff) normalized() Backoff {
+	if b.Min <= 0 {
+		b.Min = time.Second
+	}
+	if b.Max <= 0 {
+		b.Max = 30 * time.Second
+	}
+	if b.Factor <= 1 {
+		b.Factor = 2
+	}
+	return b
+}
+
+// delay computes the backoff for the attempt'th restart (0-indexed), so
+// delay(0) == Min.
+func (b Backoff) delay(attempt int) time.Duration {
+	b = b.normalized()
+	d := float64(b.Min) * math.Pow(b.Factor, float64(attempt))
+	if max := float64(b.Max); d > max {
+		d = max
+	}
+	jitter := d * rand.Float64() * 0.2
+	return time.Duration(d + jitter)
+}
+
+// SupervisorState reports a managed command's current lifecycle state.
+type SupervisorState int
+
+const (
+	// StateRunning means the command's process is currently executing.
+	StateRunning SupervisorState = iota
+	// StateRestarting means the command exited and the supervisor is
+	// waiting out its backoff delay before the next attempt.
+	StateRestarting
+	// StateStopped means the command won't run again: its policy ruled
+	// out a restart, or Stop/StopAll was called.
+	StateStopped
+	// StateCrashLooped means the crash-loop detector gave up: the
+	// command restarted more times than the configured limit allows
+	// inside the configured window.
+	StateCrashLooped
+)
+
+// SupervisorEventKind identifies what a SupervisorEvent reports.
+type SupervisorEventKind string
+
+const (
+	// SupervisorStarted marks a managed command's process starting.
+	SupervisorStarted SupervisorEventKind = "started"
+	// SupervisorExited marks a managed command's process exiting.
+	SupervisorExited SupervisorEventKind = "exited"
+	// SupervisorRestarting marks the supervisor committing to a
+	// restart and beginning its backoff delay.
+	SupervisorRestarting SupervisorEventKind = "restarting"
+	// SupervisorBackoffExhausted marks the crash-loop detector giving
+	// up on a command; it will not be restarted again.
+	SupervisorBackoffExhausted SupervisorEventKind = "backoff_exhausted"
+)
+
+// SupervisorEvent is a single timestamped occurrence delivered on the
+// channel returned by Supervisor.Events. ID names which managed command
+// it's about; Pid is populated for SupervisorStarted, Delay for
+// SupervisorRestarting, and Result for every kind except
+// SupervisorStarted.
+type SupervisorEvent struct {
+	ID     string
+	Kind   SupervisorEventKind
+	Delay  time.Duration
+	Pid    int
+	Result Result
+}
+
+// ErrSupervisorNotFound is returned by Stop and Status for an id that
+// Add never returned.
+var ErrSupervisorNotFound = errors.New("execx: supervisor: unknown id")
+
+// SupervisorOption configures a Supervisor.
+type SupervisorOption func(*Supervisor)
+
+// WithCrashLoopLimit enables the crash-loop detector: once a managed
+// command has restarted more than limit times inside window, the
+// supervisor stops restarting it and moves it to StateCrashLooped
+// instead. Disabled (the default) when limit <= 0.
+// @group Supervisor
+//
+// Example: crash loop limit
+//
+//	sup := execx.NewSupervisor(execx.WithCrashLoopLimit(5, time.Minute))
+//	fmt.Println(sup != nil)
+//	// #bool true
+func WithCrashLoopLimit(limit int, window time.Duration) SupervisorOption {
+	return func(s *Supervisor) {
+		s.crashLoopLimit = limit
+		s.crashLoopWindow = window
+	}
+}
+
+// WithEventsBuffer sets the buffer size of the channel returned by
+// Events. Events beyond the buffer are dropped rather than blocking the
+// supervisor; the default buffer is 64.
+// @group Supervisor
+//
+// Example: events buffer
+//
+//	sup := execx.NewSupervisor(execx.WithEventsBuffer(256))
+//	fmt.Println(cap(sup.Events()))
+//	// #int 256
+func WithEventsBuffer(n int) SupervisorOption {
+	return func(s *Supervisor) { s.eventsBuf = n }
+}
+
+// supervisedCmd is one command Add registered, along with its restart
+// bookkeeping. factory is called again for every attempt rather than
+// reusing a single *Cmd, since a Cmd (like the exec.Cmd it wraps) can't
+// be rerun once started.
+type supervisedCmd struct {
+	id      string
+	factory func() *Cmd
+	policy  RestartPolicy
+	backoff Backoff
+
+	mu         sync.Mutex
+	state      SupervisorState
+	proc       *Process
+	restarts   int
+	attempts   []time.Time
+	lastResult Result
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+func (sc *supervisedCmd) requestStop() {
+	sc.stopOnce.Do(func() { close(sc.stop) })
+}
+
+func (sc *supervisedCmd) setState(st SupervisorState) {
+	sc.mu.Lock()
+	sc.state = st
+	sc.mu.Unlock()
+}
+
+// Supervisor manages a set of long-running commands, restarting each
+// according to its own RestartPolicy and Backoff, and reporting what it
+// does on the channel returned by Events. Modeled loosely on
+// containerd's supervisor: callers Add commands up front, then Start
+// the supervisor to launch and keep all of them running.
+type Supervisor struct {
+	mu     sync.Mutex
+	cmds   map[string]*supervisedCmd
+	nextID int
+
+	crashLoopLimit  int
+	crashLoopWindow time.Duration
+
+	eventsBuf int
+	events    chan SupervisorEvent
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewSupervisor creates an empty Supervisor. Add commands to it, then
+// call Start to launch them.
+// @group Supervisor
+//
+// Example: new supervisor
+//
+//	sup := execx.NewSupervisor()
+//	fmt.Println(sup != nil)
+//	// #bool true
+func NewSupervisor(opts ...SupervisorOption) *Supervisor {
+	s := &Supervisor{
+		cmds:      make(map[string]*supervisedCmd),
+		eventsBuf: 64,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.events = make(chan SupervisorEvent, s.eventsBuf)
+	return s
+}
+
+// Add registers factory as a managed command under policy and backoff,
+// returning an id that Stop and Status use to refer back to it. factory
+// is called once per attempt (the first run and every restart), so it
+// should build an equivalent fresh *Cmd each time rather than reusing
+// one across calls.
+// @group Supervisor
+//
+// Example: add
+//
+//	sup := execx.NewSupervisor()
+//	id := sup.Add(func() *execx.Cmd { return execx.Command("go", "env", "GOOS") },
+//		execx.RestartNever, execx.Backoff{})
+//	fmt.Println(id != "")
+//	// #bool true
+func (s *Supervisor) Add(factory func() *Cmd, policy RestartPolicy, backoff Backoff) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	id := fmt.Sprintf("sup-%d", s.nextID)
+	s.cmds[id] = &supervisedCmd{
+		id:      id,
+		factory: factory,
+		policy:  policy,
+		backoff: backoff.normalized(),
+		state:   StateStopped,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	return id
+}
+
+// Start launches every command Add has registered so far, each in its
+// own goroutine, and ties their restart loops to ctx: cancelling ctx
+// stops any further attempt or restart, but an attempt already running
+// is not signaled and runs until it exits (StopAll also signals running
+// attempts). Commands added after Start has already run are not
+// launched retroactively; call Start again (it only launches commands
+// still in StateStopped) once they exist.
+// @group Supervisor
+//
+// Example: supervisor start
+//
+//	sup := execx.NewSupervisor()
+//	sup.Add(func() *execx.Cmd { return execx.Command("go", "env", "GOOS") },
+//		execx.RestartNever, execx.Backoff{})
+//	sup.Start(context.Background())
+//	time.Sleep(50 * time.Millisecond)
+//	fmt.Println(sup != nil)
+//	// #bool true
+func (s *Supervisor) Start(ctx context.Context) {
+	s.mu.Lock()
+	if s.ctx == nil {
+		s.ctx, s.cancel = context.WithCancel(ctx)
+	}
+	var toRun []*supervisedCmd
+	for _, sc := range s.cmds {
+		sc.mu.Lock()
+		if sc.state == StateStopped {
+			sc.state = StateRunning
+			toRun = append(toRun, sc)
+		}
+		sc.mu.Unlock()
+	}
+	s.mu.Unlock()
+
+	for _, sc := range toRun {
+		go s.run(sc)
+	}
+}
+
+func (s *Supervisor) run(sc *supervisedCmd) {
+	defer sc.doneOnce.Do(func() { close(sc.done) })
+
+	for attempt := 0; ; attempt++ {
+		select {
+		case <-sc.stop:
+			sc.setState(StateStopped)
+			return
+		case <-s.ctx.Done():
+			sc.setState(StateStopped)
+			return
+		default:
+		}
+
+		cmd := sc.factory()
+		proc := cmd.Start()
+		sc.mu.Lock()
+		sc.proc = proc
+		sc.mu.Unlock()
+		s.emit(SupervisorEvent{ID: sc.id, Kind: SupervisorStarted, Pid: proc.Pid()})
+
+		res, _ := proc.Wait()
+
+		sc.mu.Lock()
+		sc.lastResult = res
+		sc.mu.Unlock()
+		s.emit(SupervisorEvent{ID: sc.id, Kind: SupervisorExited, Result: res})
+
+		select {
+		case <-sc.stop:
+			sc.setState(StateStopped)
+			return
+		default:
+		}
+		if !sc.shouldRestart(res) {
+			sc.setState(StateStopped)
+			return
+		}
+
+		sc.mu.Lock()
+		sc.attempts = append(sc.attempts, time.Now())
+		if s.crashLoopLimit > 0 {
+			sc.attempts = pruneBefore(sc.attempts, time.Now().Add(-s.crashLoopWindow))
+			if len(sc.attempts) > s.crashLoopLimit {
+				sc.state = StateCrashLooped
+				sc.mu.Unlock()
+				s.emit(SupervisorEvent{ID: sc.id, Kind: SupervisorBackoffExhausted, Result: res})
+				return
+			}
+		}
+		sc.restarts++
+		restartNum := sc.restarts
+		sc.state = StateRestarting
+		sc.mu.Unlock()
+
+		delay := sc.backoff.delay(restartNum - 1)
+		s.emit(SupervisorEvent{ID: sc.id, Kind: SupervisorRestarting, Delay: delay, Result: res})
+
+		select {
+		case <-time.After(delay):
+		case <-sc.stop:
+			sc.setState(StateStopped)
+			return
+		case <-s.ctx.Done():
+			sc.setState(StateStopped)
+			return
+		}
+		sc.setState(StateRunning)
+	}
+}
+
+func (sc *supervisedCmd) shouldRestart(res Result) bool {
+	switch sc.policy {
+	case RestartAlways:
+		return true
+	case RestartNever:
+		return false
+	default:
+		return !res.OK()
+	}
+}
+
+func pruneBefore(ts []time.Time, cutoff time.Time) []time.Time {
+	kept := ts[:0]
+	for _, t := range ts {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
+
+func (s *Supervisor) emit(ev SupervisorEvent) {
+	select {
+	case s.events <- ev:
+	default:
+		// Default policy, matching eventChannel: drop the newest event
+		// rather than block the supervisor on a full buffer.
+	}
+}
+
+// Events returns the channel SupervisorEvents are delivered on. It's
+// never closed, since a Supervisor's managed set can grow after Start
+// via further Add/Start calls; stop reading from it once StopAll
+// returns.
+// @group Supervisor
+//
+// Example: supervisor events
+//
+//	sup := execx.NewSupervisor()
+//	fmt.Println(sup.Events() != nil)
+//	// #bool true
+func (s *Supervisor) Events() <-chan SupervisorEvent {
+	return s.events
+}
+
+func (s *Supervisor) lookup(id string) (*supervisedCmd, error) {
+	s.mu.Lock()
+	sc, ok := s.cmds[id]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrSupervisorNotFound
+	}
+	return sc, nil
+}
+
+// Stop immediately terminates id's current attempt, if any, and
+// prevents it from being restarted. Returns ErrSupervisorNotFound for an
+// id Add never returned.
+// @group Supervisor
+//
+// Example: stop
+//
+//	sup := execx.NewSupervisor()
+//	id := sup.Add(func() *execx.Cmd { return execx.Command("sleep", "2") },
+//		execx.RestartAlways, execx.Backoff{})
+//	sup.Start(context.Background())
+//	time.Sleep(20 * time.Millisecond)
+//	err := sup.Stop(id)
+//	fmt.Println(err == nil)
+//	// #bool true
+func (s *Supervisor) Stop(id string) error {
+	sc, err := s.lookup(id)
+	if err != nil {
+		return err
+	}
+	sc.requestStop()
+	sc.mu.Lock()
+	proc := sc.proc
+	sc.mu.Unlock()
+	if proc == nil {
+		return nil
+	}
+	return proc.Terminate()
+}
+
+// StopAll stops every managed command and cancels the context Start was
+// given, so nothing restarts afterward. Each command still running is
+// sent GracefulShutdown(os.Interrupt, graceful) when graceful > 0, or
+// Terminated immediately when graceful <= 0. Returns the first error
+// encountered, if any, after attempting every command regardless.
+// @group Supervisor
+//
+// Example: stop all
+//
+//	sup := execx.NewSupervisor()
+//	sup.Add(func() *execx.Cmd { return execx.Command("sleep", "2") },
+//		execx.RestartAlways, execx.Backoff{})
+//	sup.Start(context.Background())
+//	time.Sleep(20 * time.Millisecond)
+//	err := sup.StopAll(100 * time.Millisecond)
+//	fmt.Println(err == nil)
+//	// #bool true
+func (s *Supervisor) StopAll(graceful time.Duration) error {
+	s.mu.Lock()
+	scs := make([]*supervisedCmd, 0, len(s.cmds))
+	for _, sc := range s.cmds {
+		scs = append(scs, sc)
+	}
+	cancel := s.cancel
+	s.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+
+	var firstErr error
+	for _, sc := range scs {
+		sc.requestStop()
+		sc.mu.Lock()
+		proc := sc.proc
+		sc.mu.Unlock()
+		if proc == nil {
+			continue
+		}
+		var err error
+		if graceful > 0 {
+			err = proc.GracefulShutdown(os.Interrupt, graceful)
+		} else {
+			err = proc.Terminate()
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Status reports id's current SupervisorState. Returns
+// ErrSupervisorNotFound for an id Add never returned.
+// @group Supervisor
+//
+// Example: status
+//
+//	sup := execx.NewSupervisor()
+//	id := sup.Add(func() *execx.Cmd { return execx.Command("go", "env", "GOOS") },
+//		execx.RestartNever, execx.Backoff{})
+//	state, err := sup.Status(id)
+//	fmt.Println(err == nil && state == execx.StateStopped)
+//	// #bool true
+func (s *Supervisor) Status(id string) (SupervisorState, error) {
+	sc, err := s.lookup(id)
+	if err != nil {
+		return 0, err
+	}
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.state, nil
+}
@@ -0,0 +1,166 @@
+package execx
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSupervisorRestartOnFailure(t *testing.T) {
+	sup := NewSupervisor()
+	attempts := 0
+	id := sup.Add(func() *Cmd {
+		attempts++
+		n := attempts
+		return helperCommand("exit", map[bool]string{true: "0", false: "1"}[n >= 3])
+	}, RestartOnFailure, Backoff{Min: 5 * time.Millisecond, Max: 5 * time.Millisecond})
+	sup.Start(context.Background())
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		state, err := sup.Status(id)
+		if err != nil {
+			t.Fatalf("status: %v", err)
+		}
+		if state == StateStopped {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	state, err := sup.Status(id)
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	if state != StateStopped {
+		t.Fatalf("expected StateStopped, got %v", state)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestSupervisorRestartNever(t *testing.T) {
+	sup := NewSupervisor()
+	attempts := 0
+	id := sup.Add(func() *Cmd {
+		attempts++
+		return helperCommand("exit", "1")
+	}, RestartNever, Backoff{})
+	sup.Start(context.Background())
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		state, err := sup.Status(id)
+		if err != nil {
+			t.Fatalf("status: %v", err)
+		}
+		if state == StateStopped {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if attempts != 1 {
+		t.Fatalf("expected exactly 1 attempt, got %d", attempts)
+	}
+}
+
+func TestSupervisorStop(t *testing.T) {
+	sup := NewSupervisor()
+	id := sup.Add(func() *Cmd {
+		return helperCommand("sleep", "2000")
+	}, RestartAlways, Backoff{})
+	sup.Start(context.Background())
+	time.Sleep(50 * time.Millisecond)
+
+	if err := sup.Stop(id); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		state, err := sup.Status(id)
+		if err != nil {
+			t.Fatalf("status: %v", err)
+		}
+		if state == StateStopped {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("expected command to reach StateStopped after Stop")
+}
+
+func TestSupervisorStopAll(t *testing.T) {
+	sup := NewSupervisor()
+	id1 := sup.Add(func() *Cmd { return helperCommand("sleep", "2000") }, RestartAlways, Backoff{})
+	id2 := sup.Add(func() *Cmd { return helperCommand("sleep", "2000") }, RestartAlways, Backoff{})
+	sup.Start(context.Background())
+	time.Sleep(50 * time.Millisecond)
+
+	if err := sup.StopAll(100 * time.Millisecond); err != nil {
+		t.Fatalf("stopall: %v", err)
+	}
+
+	for _, id := range []string{id1, id2} {
+		state, err := sup.Status(id)
+		if err != nil {
+			t.Fatalf("status: %v", err)
+		}
+		if state != StateStopped {
+			t.Fatalf("expected %s to be StateStopped, got %v", id, state)
+		}
+	}
+}
+
+func TestSupervisorEvents(t *testing.T) {
+	sup := NewSupervisor()
+	id := sup.Add(func() *Cmd {
+		return helperCommand("exit", "0")
+	}, RestartNever, Backoff{})
+	sup.Start(context.Background())
+
+	var sawStart, sawExit bool
+	deadline := time.Now().Add(2 * time.Second)
+	for !(sawStart && sawExit) && time.Now().Before(deadline) {
+		select {
+		case ev := <-sup.Events():
+			if ev.ID != id {
+				t.Fatalf("expected event for %s, got %s", id, ev.ID)
+			}
+			switch ev.Kind {
+			case SupervisorStarted:
+				sawStart = true
+				if ev.Pid <= 0 {
+					t.Fatalf("expected a positive pid, got %d", ev.Pid)
+				}
+			case SupervisorExited:
+				sawExit = true
+			}
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	if !sawStart || !sawExit {
+		t.Fatalf("expected both started and exited events, got start=%v exit=%v", sawStart, sawExit)
+	}
+}
+
+func TestSupervisorCrashLoopLimit(t *testing.T) {
+	sup := NewSupervisor(WithCrashLoopLimit(2, time.Minute))
+	id := sup.Add(func() *Cmd {
+		return helperCommand("exit", "1")
+	}, RestartAlways, Backoff{Min: 5 * time.Millisecond, Max: 5 * time.Millisecond})
+	sup.Start(context.Background())
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		state, err := sup.Status(id)
+		if err != nil {
+			t.Fatalf("status: %v", err)
+		}
+		if state == StateCrashLooped {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("expected command to reach StateCrashLooped")
+}